@@ -0,0 +1,84 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// `age <date>` and `since <date>`
+func ageItems(match []string) Items {
+	dt, err := evaluateDate(match[2], loadEnvironment())
+	if err == nil && dt.kind != timestamp {
+		err = fmt.Errorf(tr("%s needs a date"), match[1])
+	}
+	if err != nil {
+		return getItems(datetime{}, err)
+	}
+
+	from := dt.dt
+	now := timeNow().Round(time.Second)
+	if from.After(now) {
+		return getItems(datetime{}, errors.New(tr("date is in the future, try until")))
+	}
+
+	span := calendarSpan(from, now)
+	ymd := joinList([]string{formatUnit(span.year, "year"), formatUnit(span.month, "month"), formatUnit(span.day, "day")})
+
+	// Whole days, counted between midnights, so not affected by DST
+	days := int64(midnight(now).Sub(midnight(from)).Hours()+12) / 24
+
+	items := Items{
+		Skipknowldedge: true,
+	}
+
+	title := tr("Age")
+	if match[1] == "since" {
+		title = tr("Since") + " " + formatDate(from)
+	}
+
+	items.Items = append(items.Items, Item{
+		Title:    title,
+		Subtitle: ymd,
+		Arg:      ymd,
+	})
+
+	totals := []struct {
+		title string
+		value string
+	}{
+		{tr("Total days"), formatUnit(days, "day")},
+		{tr("Total weeks"), joinList([]string{formatUnit(days/7, "week"), formatUnit(days%7, "day")})},
+	}
+
+	for _, t := range totals {
+		items.Items = append(items.Items, Item{
+			Title:    t.title,
+			Subtitle: t.value,
+			Arg:      t.value,
+		})
+	}
+
+	// Next anniversary, today's counts as the next one
+	years := int(span.year)
+	next := addMonths(from, years, 0)
+	if midnight(next).Before(midnight(now)) {
+		years++
+		next = addMonths(from, years, 0)
+	}
+	left := int64(midnight(next).Sub(midnight(now)).Hours()+12) / 24
+
+	anniversary := fmt.Sprintf(tr("%s, in %s (%s)"), formatDate(next), formatUnit(left, "day"), formatUnit(int64(years), "year"))
+	if left == 0 {
+		anniversary = fmt.Sprintf(tr("Today! (%s)"), formatUnit(int64(years), "year"))
+	}
+
+	items.Items = append(items.Items, Item{
+		Title:    tr("Next anniversary"),
+		Subtitle: anniversary,
+		Arg:      next.Format("2006-01-02"),
+	})
+
+	items.Items = append(items.Items, resultItems(span)...)
+	return items
+}
@@ -0,0 +1,186 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// `round <expr> to <increment>`, also `round up` and `round down`
+// optionally followed by further calculation, e.g. `round up 7h38m to 15m * 120/h`
+var roundRegex = regexp.MustCompile(`^round(?: (up|down))? (.+?) to (\S+)(.*)$`)
+
+// `120/h`, `2.50/m`, `800/d` hourly (or per minute, per day) rate
+var rateRegex = regexp.MustCompile(`^([0-9]+)(?:\.([0-9]{1,2}))?/(h|m|d)$`)
+
+// Rate as cents per unit, `h`, `m` or `d`
+func parseRate(f string) (int64, string, bool) {
+	match := rateRegex.FindStringSubmatch(f)
+	if match == nil {
+		return 0, "", false
+	}
+
+	cents := Atoi(match[1]) * 100
+	if match[2] != "" {
+		fraction := match[2]
+		if len(fraction) == 1 {
+			fraction += "0"
+		}
+		cents += Atoi(fraction)
+	}
+	return cents, match[3], true
+}
+
+// Seconds billed per unit of a rate, a day is a working day as in WORK_HOURS
+func rateSeconds(unit string) int64 {
+	switch unit {
+	case "m":
+		return 60
+	case "d":
+		start, end, lunchStart, lunchEnd := workSchedule()
+		return int64((end - start - (lunchEnd - lunchStart)).Seconds())
+	}
+	return 3600
+}
+
+// a / b rounded half away from zero
+func divRound(a int64, b int64) int64 {
+	if (a < 0) != (b < 0) {
+		return (a - b/2) / b
+	}
+	return (a + b/2) / b
+}
+
+// Round duration to a billing increment, half up when nearest
+// Negative durations are rounded by magnitude, so round up -7h31s to 15m is -7h15m
+func roundDuration(s int64, increment int64, mode string) int64 {
+	if increment <= 0 {
+		return s
+	} else if s < 0 {
+		return -roundDuration(-s, increment, mode)
+	}
+
+	switch mode {
+	case "up":
+		s += increment - 1
+	case "down":
+	default:
+		s += increment / 2
+	}
+	return s - s%increment
+}
+
+func evaluateRound(match []string, env environment) (datetime, error) {
+	dt, err := evaluate(match[2], env)
+	if err != nil {
+		return dt, err
+	}
+
+	increment, err := evaluate(match[3], env)
+	// `round 90m to h`, a unit alone is one of it
+	if err != nil && !strings.ContainsAny(match[3], "0123456789") {
+		if unit, unitErr := evaluate("1"+match[3], env); unitErr == nil {
+			increment, err = unit, nil
+		}
+	}
+	if err != nil {
+		return increment, err
+	}
+
+	if dt.kind&(duration|workTime) == 0 || increment.kind&duration == 0 {
+		return datetime{}, errors.New(tr("allowed format: round [up|down] <duration> to <duration>"))
+	}
+
+	result := datetime{
+		kind: dt.kind,
+		ts:   roundDuration(dt.ts, increment.ts, match[1]),
+	}
+	result.updateDT(ts)
+
+	// rounded value continues as `_`
+	if rest := strings.TrimSpace(match[4]); rest != "" {
+		scope := environment{}
+		for name, v := range env {
+			scope[name] = v
+		}
+		scope["_"] = result
+		return evaluate("_ "+rest, scope)
+	}
+	return result, nil
+}
+
+// Arithmetic where one side is an amount or a rate
+// Amounts are kept in cents, rates in cents per dt.per, e.g. `h`
+func (dt *datetime) calculateMoney(dt1 datetime, dt2 datetime, operation int) error {
+	isDuration := func(dt datetime) bool {
+		return dt.kind&(duration|workTime) != 0 && dt.kind&number == 0
+	}
+
+	switch {
+	case operation == mul && isDuration(dt1) && dt2.kind == rate:
+		// 7h45m * 120/h -> 930.00
+		dt.kind = money
+		dt.ts = divRound(dt1.ts*dt2.ts, rateSeconds(dt2.per))
+	case operation == mul && dt1.kind == rate && isDuration(dt2):
+		return dt.calculateMoney(dt2, dt1, mul)
+	case operation == mul && dt1.kind&number != 0 && (dt2.kind == rate || dt2.kind == money):
+		return dt.calculateMoney(dt2, dt1, mul)
+	case operation == mul && (dt1.kind == rate || dt1.kind == money) && dt2.kind&number != 0:
+		// 930.00 * 2 -> 1860.00
+		dt.kind, dt.per = dt1.kind, dt1.per
+		dt.ts = dt1.ts * dt2.ts
+	case operation == div && (dt1.kind == rate || dt1.kind == money) && dt2.kind&number != 0:
+		if dt2.ts == 0 {
+			return errors.New(tr("division by zero"))
+		}
+		dt.kind, dt.per = dt1.kind, dt1.per
+		dt.ts = divRound(dt1.ts, dt2.ts)
+	case (operation == add || operation == sub) && dt1.kind == dt2.kind && dt1.per == dt2.per:
+		dt.kind, dt.per = dt1.kind, dt1.per
+		dt.ts = dt1.ts + dt2.ts
+		if operation == sub {
+			dt.ts = dt1.ts - dt2.ts
+		}
+	case (operation == add || operation == sub) && dt1.kind == rate && dt2.kind == rate:
+		return errors.New(tr("rates per different units, e.g. 120/h + 2/m"))
+	default:
+		return errors.New(tr("amounts and rates go with durations, numbers and their own kind only"))
+	}
+	return nil
+}
+
+// 93000 -> 930.00, 123456700 -> 1,234,567.00
+func formatCents(cents int64) string {
+	sign := ""
+	if cents < 0 {
+		sign, cents = "-", -cents
+	}
+	_, decimal := numberSeparators()
+	return fmt.Sprintf("%s%s%s%02d", sign, formatInt(cents/100), decimal, cents%100)
+}
+
+// 123456700 -> 1234567.00, without digit grouping for copying
+func plainCents(cents int64) string {
+	sign := ""
+	if cents < 0 {
+		sign, cents = "-", -cents
+	}
+	_, decimal := numberSeparators()
+	return fmt.Sprintf("%s%d%s%02d", sign, cents/100, decimal, cents%100)
+}
+
+// Amount with CURRENCY, a symbol goes before the amount, a code after
+func formatMoney(cents int64) string {
+	currency := getConfig("CURRENCY", "")
+	amount := formatCents(cents)
+
+	if currency == "" {
+		return amount
+	} else if len(currency) == 3 && strings.ToUpper(currency) == currency && strings.ToLower(currency) != currency {
+		return amount + " " + currency
+	} else if strings.HasPrefix(amount, "-") {
+		return "-" + currency + amount[1:]
+	}
+	return currency + amount
+}
@@ -0,0 +1,183 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Monday of ISO week 1, the week with the year's first Thursday
+func isoWeekOne(year int) time.Time {
+	jan4 := time.Date(year, time.January, 4, 0, 0, 0, 0, time.Local)
+	return jan4.AddDate(0, 0, -((int(jan4.Weekday()) + 6) % 7))
+}
+
+// 52 or 53, 28 December is always in the last week
+func isoWeeksInYear(year int) int {
+	_, week := time.Date(year, time.December, 28, 0, 0, 0, 0, time.Local).ISOWeek()
+	return week
+}
+
+// Monday of the ISO week
+func isoWeekStart(year int, week int) time.Time {
+	return isoWeekOne(year).AddDate(0, 0, 7*(week-1))
+}
+
+// `2024-W47`
+func formatISOWeek(t time.Time) string {
+	year, week := t.ISOWeek()
+	return fmt.Sprintf("%d-W%02d", year, week)
+}
+
+// Calendar days from one date to another, DST changes don't matter
+func daysBetween(from, to time.Time) int64 {
+	f := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	t := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	return int64(t.Sub(f).Hours() / 24)
+}
+
+// Sprint calendar, SPRINT_START (first day of sprint 1) and SPRINT_LENGTH (days) variables
+func sprintCalendar() (time.Time, int, error) {
+	length := int(Atoi(getConfig("SPRINT_LENGTH", "14")))
+	if length < 1 {
+		return time.Time{}, 0, errors.New(tr("SPRINT_LENGTH must be a number of days"))
+	}
+
+	start := getConfig("SPRINT_START", "")
+	if start == "" {
+		return time.Time{}, 0, errors.New(tr("set SPRINT_START to the first day of sprint 1"))
+	}
+
+	anchor, ok := parseDate(start)
+	if !ok {
+		return time.Time{}, 0, fmt.Errorf(tr("SPRINT_START is not a date: %s"), start)
+	}
+	return midnight(anchor), length, nil
+}
+
+// Date of `week of <date>`, `sprint of <date>`, today if empty
+func calendarDate(p string) (time.Time, error) {
+	if p == "" {
+		return midnight(timeNow()), nil
+	}
+
+	dt, err := evaluateDate(p, loadEnvironment())
+	if err == nil && dt.kind&timestamp == 0 {
+		err = errors.New(tr("needs a date, e.g. 22/11/2024"))
+	}
+	return midnight(dt.dt), err
+}
+
+// `week`, `week <n>`, `week <n> <year>`, `week of <date>`
+func weekItems(match []string) Items {
+	var start time.Time
+
+	if match[2] != "" {
+		year, _ := timeNow().ISOWeek()
+		if match[3] != "" {
+			year = int(Atoi(match[3]))
+		}
+
+		week := int(Atoi(match[2]))
+		if week < 1 || week > isoWeeksInYear(year) {
+			return getItems(datetime{}, fmt.Errorf(tr("%d has weeks 1 to %d"), year, isoWeeksInYear(year)))
+		}
+		start = isoWeekStart(year, week)
+	} else {
+		t, err := calendarDate(match[1])
+		if err != nil {
+			return getItems(datetime{}, err)
+		}
+		start = t.AddDate(0, 0, -((int(t.Weekday()) + 6) % 7))
+	}
+
+	year, week := start.ISOWeek()
+	return calendarItems(fmt.Sprintf("%s %d, %d", tr("Week"), week, year), start, start.AddDate(0, 0, 6))
+}
+
+// `sprint`, `sprint <n>`, `sprint of <date>`
+func sprintItems(match []string) Items {
+	anchor, length, err := sprintCalendar()
+	if err != nil {
+		return getItems(datetime{}, err)
+	}
+
+	var n int
+	if match[2] != "" {
+		n = int(Atoi(match[2]))
+	} else {
+		t, err := calendarDate(match[1])
+		if err != nil {
+			return getItems(datetime{}, err)
+		}
+
+		days := daysBetween(anchor, t)
+		if days < 0 {
+			return getItems(datetime{}, errors.New(tr("date is before sprint 1")))
+		}
+		n = int(days)/length + 1
+	}
+
+	if n < 1 {
+		return getItems(datetime{}, errors.New(tr("sprints are counted from 1")))
+	}
+
+	start := anchor.AddDate(0, 0, (n-1)*length)
+	return calendarItems(fmt.Sprintf("%s %d", tr("Sprint"), n), start, start.AddDate(0, 0, length-1))
+}
+
+// Start, end, ISO weeks and days remaining of a period, both days included
+func calendarItems(title string, start, end time.Time) Items {
+	weeks := formatISOWeek(start)
+	if formatISOWeek(end) != weeks {
+		weeks += " – " + formatISOWeek(end)
+	}
+
+	items := Items{
+		Skipknowldedge: true,
+		Items: []Item{
+			{
+				Title:    title,
+				Subtitle: formatDate(start) + " – " + formatDate(end),
+				Arg:      start.Format("2006-01-02") + " – " + end.Format("2006-01-02"),
+			},
+			{
+				Title:    tr("Start"),
+				Subtitle: formatDate(start),
+				Arg:      start.Format("2006-01-02"),
+			},
+			{
+				Title:    tr("End"),
+				Subtitle: formatDate(end),
+				Arg:      end.Format("2006-01-02"),
+			},
+			{
+				Title:    tr("ISO week"),
+				Subtitle: weeks,
+				Arg:      weeks,
+			},
+		},
+	}
+
+	today := midnight(timeNow())
+
+	var label string
+	var days int64
+	switch {
+	case today.Before(start):
+		label, days = tr("Days until start"), daysBetween(today, start)
+	case today.After(end):
+		label, days = tr("Days since end"), daysBetween(end, today)
+	default:
+		// today included
+		label, days = tr("Days remaining"), daysBetween(today, end)+1
+	}
+
+	items.Items = append(items.Items, Item{
+		Title:    label,
+		Subtitle: formatUnit(days, "day"),
+		Arg:      strconv.FormatInt(days, 10),
+	})
+	return items
+}
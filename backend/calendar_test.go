@@ -0,0 +1,69 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestISOWeeks(t *testing.T) {
+	tests := []struct {
+		year  int
+		week  int
+		start string
+		weeks int
+	}{
+		{2024, 47, "2024-11-18", 52},
+		{2025, 1, "2024-12-30", 52},
+		{2026, 53, "2026-12-28", 53},
+		{2021, 1, "2021-01-04", 52},
+	}
+
+	for _, ts := range tests {
+		start := isoWeekStart(ts.year, ts.week).Format("2006-01-02")
+		weeks := isoWeeksInYear(ts.year)
+
+		if start != ts.start || weeks != ts.weeks {
+			t.Error(">>> Input", ts.year, ts.week)
+			t.Errorf(">>> Expected %s, %d weeks\n", ts.start, ts.weeks)
+			t.Errorf(">>> Result   %s, %d weeks\n", start, weeks)
+		}
+	}
+}
+
+func TestWeekAndSprint(t *testing.T) {
+	timeNow = func() time.Time { return time.Date(2025, 7, 10, 12, 0, 0, 0, time.Local) }
+	defer func() { timeNow = time.Now }()
+
+	t.Setenv("SPRINT_START", "2025-01-06")
+	t.Setenv("SPRINT_LENGTH", "14")
+
+	tests := []struct {
+		input    string
+		expected []string
+	}{
+		{"week 47 2024", []string{"2024-11-18 – 2024-11-24", "2024-11-18", "2024-11-24", "2024-W47", "228"}},
+		{"week of 22/11/2024", []string{"2024-11-18 – 2024-11-24", "2024-11-18", "2024-11-24", "2024-W47", "228"}},
+		{"week 1", []string{"2024-12-30 – 2025-01-05", "2024-12-30", "2025-01-05", "2025-W01", "186"}},
+		{"sprint 14", []string{"2025-07-07 – 2025-07-20", "2025-07-07", "2025-07-20", "2025-W28 – 2025-W29", "11"}},
+		{"sprint of 01/08/2025", []string{"2025-07-21 – 2025-08-03", "2025-07-21", "2025-08-03", "2025-W30 – 2025-W31", "11"}},
+		{"week 53 2025", []string{"error", "open"}},
+		{"sprint 0", []string{"error", "open"}},
+		{"sprint of 01/01/2025", []string{"error", "open"}},
+	}
+
+	for _, ts := range tests {
+		items, ok := runCommand(ts.input)
+
+		var result []string
+		for _, item := range items.Items {
+			result = append(result, item.Arg)
+		}
+
+		if !ok || strings.Join(result, "|") != strings.Join(ts.expected, "|") {
+			t.Error(">>> Input", ts.input)
+			t.Errorf(">>> Expected %q\n", ts.expected)
+			t.Errorf(">>> Result   %q\n", result)
+		}
+	}
+}
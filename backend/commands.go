@@ -0,0 +1,258 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// Prefix of Arg for items which, when actioned, change stored state
+// instead of being copied to clipboard
+const actionPrefix = "cmd:"
+
+// Query which isn't an expression, e.g. `vars`
+type command struct {
+	regex       string
+	commandFunc func(match []string) Items
+}
+
+// Action executed by `timecalculator --action <arg>`
+type action struct {
+	regex      string
+	actionFunc func(match []string) (string, error)
+}
+
+var commands = []command{
+	//   - `vars`
+	{
+		regex:       `^vars$`,
+		commandFunc: variablesItems,
+	},
+	//   - `del <name>`
+	{
+		regex: `^del ([a-zA-Z_][a-zA-Z0-9_]*)$`,
+		commandFunc: func(match []string) Items {
+			return Items{
+				Skipknowldedge: true,
+				Items: []Item{
+					{
+						Title:    fmt.Sprintf(tr("Delete variable %s"), match[1]),
+						Subtitle: tr("Press Enter to delete"),
+						Arg:      actionPrefix + "del " + match[1],
+					},
+				},
+			}
+		},
+	},
+	//   - `until <date>`
+	{
+		regex:       `^until (.+)$`,
+		commandFunc: untilItems,
+	},
+	//   - `age <date>`, `since <date>`
+	{
+		regex:       `^(age|since) (.+)$`,
+		commandFunc: ageItems,
+	},
+	//   - `bd between <date> and <date>`, `workdays <date> and <date>`
+	{
+		regex:       `^(bd|workdays) (?:between )?(.+) and (.+)$`,
+		commandFunc: businessDaysItems,
+	},
+	//   - `work hours between <date> and <date>`
+	{
+		regex:       `^work(?:ing)? (?:hours|time) (?:between )?(.+) and (.+)$`,
+		commandFunc: workTimeItems,
+	},
+	//   - `holidays`, `holidays <year>`
+	{
+		regex:       `^holidays(?: ([0-9]{4}))?$`,
+		commandFunc: holidaysItems,
+	},
+	//   - `cron <expr>`, `cron <expr> after <date>`, `cron <expr> before <date>`
+	{
+		regex:       `^cron (.+?)(?: (after|before) (.+))?$`,
+		commandFunc: cronItems,
+	},
+	//   - `rrule <rule> from <date>`, `rrule <rule> between <date> and <date>`
+	{
+		regex:       `^(?i)rrule (\S+)(?: from (.+?))?(?: between (.+) and (.+))?$`,
+		commandFunc: rruleItems,
+	},
+	//   - `every <period> from <date> until <date>`, `every <period> from <date> for <n>`
+	{
+		regex:       `^every (.+?) from (.+?)(?: (?:until|to) (.+)| for ([0-9]+)(?: times)?)?$`,
+		commandFunc: sequenceItems,
+	},
+	//   - `week`, `week <n>`, `week <n> <year>`, `week of <date>`
+	{
+		regex:       `^week(?: of (.+)| ([0-9]{1,2})(?: ([0-9]{4}))?)?$`,
+		commandFunc: weekItems,
+	},
+	//   - `sprint`, `sprint <n>`, `sprint of <date>`
+	{
+		regex:       `^sprint(?: of (.+)| ([0-9]+))?$`,
+		commandFunc: sprintItems,
+	},
+	//   - `timers`
+	{
+		regex:       `^timers$`,
+		commandFunc: timersListItems,
+	},
+	//   - `start <name>`
+	{
+		regex: `^start ([a-zA-Z_][a-zA-Z0-9_]*)$`,
+		commandFunc: func(match []string) Items {
+			return timerItems(match[1], fmt.Sprintf(tr("Start timer %s"), match[1]), actionPrefix+"start "+match[1])
+		},
+	},
+	//   - `lap <name>`
+	{
+		regex: `^lap ([a-zA-Z_][a-zA-Z0-9_]*)$`,
+		commandFunc: func(match []string) Items {
+			return timerItems(match[1], fmt.Sprintf(tr("Record lap of %s"), match[1]), actionPrefix+"lap "+match[1])
+		},
+	},
+	//   - `stop <name>`
+	{
+		regex: `^stop ([a-zA-Z_][a-zA-Z0-9_]*)$`,
+		commandFunc: func(match []string) Items {
+			return timerItems(match[1], fmt.Sprintf(tr("Stop timer %s"), match[1]), actionPrefix+"stop "+match[1])
+		},
+	},
+	//   - `reset <name>`
+	{
+		regex: `^reset ([a-zA-Z_][a-zA-Z0-9_]*)$`,
+		commandFunc: func(match []string) Items {
+			return timerItems(match[1], fmt.Sprintf(tr("Delete timer %s"), match[1]), actionPrefix+"reset "+match[1])
+		},
+	},
+}
+
+var actions = []action{
+	{
+		regex: `^start (.*)$`,
+		actionFunc: func(match []string) (string, error) {
+			return fmt.Sprintf(tr("Timer %s started"), match[1]), startTimer(match[1])
+		},
+	},
+	{
+		regex: `^lap (.*)$`,
+		actionFunc: func(match []string) (string, error) {
+			return fmt.Sprintf(tr("Lap of %s recorded"), match[1]), updateTimer(match[1], false)
+		},
+	},
+	{
+		regex: `^stop (.*)$`,
+		actionFunc: func(match []string) (string, error) {
+			return fmt.Sprintf(tr("Timer %s stopped"), match[1]), updateTimer(match[1], true)
+		},
+	},
+	{
+		regex: `^reset (.*)$`,
+		actionFunc: func(match []string) (string, error) {
+			return fmt.Sprintf(tr("Timer %s deleted"), match[1]), deleteTimer(match[1])
+		},
+	},
+	{
+		regex: `^clear history$`,
+		actionFunc: func(match []string) (string, error) {
+			return tr("History cleared"), clearHistory()
+		},
+	},
+	{
+		regex: `^let (.*)$`,
+		actionFunc: func(match []string) (string, error) {
+			name, err := saveVariable(match[1])
+			return fmt.Sprintf(tr("Variable %s saved"), name), err
+		},
+	},
+	{
+		regex: `^del (.*)$`,
+		actionFunc: func(match []string) (string, error) {
+			return fmt.Sprintf(tr("Variable %s deleted"), match[1]), deleteVariable(match[1])
+		},
+	},
+}
+
+// Try commands, ok is false if query is not a command
+func runCommand(p string) (Items, bool) {
+	p = strings.Join(strings.Fields(p), " ")
+
+	for _, c := range commands {
+		re := regexp.MustCompile(c.regex)
+		match := re.FindStringSubmatch(p)
+
+		if match != nil {
+			return c.commandFunc(match), true
+		}
+	}
+	return Items{}, false
+}
+
+// Execute an action chosen in Alfred
+// Anything not prefixed with actionPrefix is a result, returned as is
+// and its query recorded in history
+func runAction(a string) string {
+	if !strings.HasPrefix(a, actionPrefix) {
+		if query := os.Getenv(historyVariable); query != "" {
+			recordHistory(query)
+		}
+		return a
+	}
+	a = strings.TrimPrefix(a, actionPrefix)
+
+	for _, c := range actions {
+		re := regexp.MustCompile(c.regex)
+		match := re.FindStringSubmatch(a)
+
+		if match != nil {
+			msg, err := c.actionFunc(match)
+			if err != nil {
+				return err.Error()
+			}
+			return msg
+		}
+	}
+	return fmt.Sprintf(tr("unknown action %s"), a)
+}
+
+func variablesItems(match []string) Items {
+	items := Items{
+		Skipknowldedge: true,
+	}
+
+	vars, err := loadStoredVariables()
+	if err != nil {
+		return getItems(datetime{}, err)
+	}
+
+	for _, name := range variableNames(vars) {
+		v := vars[name]
+		result := formatResult(v.datetime())
+
+		items.Items = append(items.Items, Item{
+			Uid:          "var " + name,
+			Title:        fmt.Sprintf("%s = %s", name, v.Query),
+			Subtitle:     result,
+			Arg:          result,
+			Autocomplete: "del " + name,
+		})
+	}
+
+	if len(items.Items) == 0 {
+		items.Items = append(items.Items, Item{
+			Title:    tr("No variables"),
+			Subtitle: tr("Define one with <name> = <expr>, e.g. standup = 15m"),
+			Arg:      "",
+			Valid:    notValid(),
+		})
+	}
+	return items
+}
+
+func notValid() *bool {
+	valid := false
+	return &valid
+}
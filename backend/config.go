@@ -0,0 +1,67 @@
+package main
+
+import (
+	"os"
+	"strings"
+	"time"
+)
+
+// Workflow configuration is passed by Alfred as environment variables
+// https://www.alfredapp.com/help/workflows/workflow-configuration/
+func getConfig(name string, def string) string {
+	if v, ok := os.LookupEnv(name); ok && v != "" {
+		return v
+	}
+	return def
+}
+
+// Date formats, DATE_FORMAT variable
+const (
+	dayFirst   = iota // DD/MM/YYYY, DD/MM
+	monthFirst        // MM/DD/YYYY, MM/DD
+)
+
+func dateOrder() int {
+	switch getConfig("DATE_FORMAT", "1") {
+	case "3", "4":
+		return monthFirst
+	}
+	return dayFirst
+}
+
+// Weekend days, WEEKEND variable
+func weekend() []time.Weekday {
+	switch getConfig("WEEKEND", "1") {
+	case "2":
+		return []time.Weekday{time.Friday, time.Saturday}
+	case "3":
+		return []time.Weekday{time.Sunday}
+	}
+	return []time.Weekday{time.Saturday, time.Sunday}
+}
+
+// Working day, WORK_HOURS and LUNCH_BREAK variables as `<hh:mm>-<hh:mm>`
+// Offsets from midnight, lunch break is empty if not configured
+func workSchedule() (start, end, lunchStart, lunchEnd time.Duration) {
+	start, end, ok := parseTimeRange(getConfig("WORK_HOURS", "09:00-17:00"))
+	if !ok {
+		start, end, _ = parseTimeRange("09:00-17:00")
+	}
+
+	lunchStart, lunchEnd, ok = parseTimeRange(getConfig("LUNCH_BREAK", ""))
+	if !ok || lunchStart < start || lunchEnd > end {
+		lunchStart, lunchEnd = 0, 0
+	}
+	return
+}
+
+func parseTimeRange(r string) (time.Duration, time.Duration, bool) {
+	from, to, found := strings.Cut(strings.ReplaceAll(r, " ", ""), "-")
+	if !found {
+		return 0, 0, false
+	}
+
+	start, ok1 := parseClock(from)
+	end, ok2 := parseClock(to)
+	return start, end, ok1 && ok2 && start < end
+}
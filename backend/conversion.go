@@ -0,0 +1,61 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// `<expr> in <format>`, `<expr> to <format>`, `<expr> as <format>`
+var conversionRegex = regexp.MustCompile(`^(.+) (?:in|to|as) ([a-zA-Z0-9:_/+-]+)$`)
+
+// Split conversion clause, only when it names an output format or a time zone,
+// so `round 7h50m to 6m` stays as is
+func parseConversion(p string) (string, string, bool) {
+	match := conversionRegex.FindStringSubmatch(strings.TrimSpace(p))
+	if match == nil {
+		return "", "", false
+	}
+
+	if !isOutputFormat(match[2]) && !isTimeZone(match[2]) {
+		return "", "", false
+	}
+	return match[1], match[2], true
+}
+
+// `UTC`, `Europe/Warsaw`, ...
+func isTimeZone(name string) bool {
+	if name != "UTC" && !strings.Contains(name, "/") {
+		return false
+	}
+	_, err := time.LoadLocation(name)
+	return err == nil
+}
+
+func evaluateConversion(expr string, target string, env environment) (datetime, error) {
+	dt, err := evaluate(expr, env)
+	if err != nil {
+		return dt, err
+	}
+
+	if isTimeZone(target) {
+		if dt.kind&timestamp == 0 {
+			return dt, fmt.Errorf(tr("only a timestamp can be shown in %s"), target)
+		}
+		loc, _ := time.LoadLocation(target)
+		dt.dt = dt.dt.In(loc)
+		return dt, nil
+	}
+
+	// JWT in a timestamp format is its expiry time
+	if _, ok := findOutputFormat(dt.kind, target); !ok && dt.kind == jwt|timestamp {
+		dt.kind = timestamp
+	}
+
+	if _, ok := findOutputFormat(dt.kind, target); !ok {
+		return dt, fmt.Errorf(tr("result can't be shown as %s"), target)
+	}
+	dt.format = target
+	return dt, nil
+}
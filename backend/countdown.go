@@ -0,0 +1,80 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Target of `until <target>`
+//   - `<hh:mm>` is today, or tomorrow if already passed
+//   - `<DD>/<MM>` is this year, or next year if already passed
+//   - anything else is evaluated and must give a timestamp
+func untilTarget(p string) (time.Time, error) {
+	now := timeNow()
+
+	if offset, ok := parseClock(p); ok {
+		t := midnight(now).Add(offset)
+		if !t.After(now) {
+			t = midnight(now).AddDate(0, 0, 1).Add(offset)
+		}
+		return t, nil
+	}
+
+	dt, err := evaluateDate(p, loadEnvironment())
+	if err != nil {
+		return time.Time{}, err
+	} else if dt.kind != timestamp {
+		return time.Time{}, errors.New(tr("until needs a date or time"))
+	}
+
+	if match := dateRegex.FindStringSubmatch(p); match != nil && match[3] == "" && dt.dt.Before(now) {
+		return dt.dt.AddDate(1, 0, 0), nil
+	}
+	return dt.dt, nil
+}
+
+func untilItems(match []string) Items {
+	target, err := untilTarget(match[1])
+	if err != nil {
+		return getItems(datetime{}, err)
+	}
+
+	now := timeNow().Round(time.Second)
+	remaining := newDuration(int64(target.Sub(now).Seconds()))
+
+	items := Items{
+		Skipknowldedge: true,
+	}
+
+	items.Items = append(items.Items, Item{
+		Title:    tr("Until") + " " + formatDate(target) + " " + formatClock(target),
+		Subtitle: formatResult(remaining),
+		Arg:      formatResult(remaining),
+	})
+	items.Items = append(items.Items, resultItems(remaining)...)
+
+	// Monday is the first day of the week
+	dayStart := midnight(now)
+	weekStart := dayStart.AddDate(0, 0, -((int(now.Weekday()) + 6) % 7))
+
+	elapsed := []struct {
+		title  string
+		start  time.Time
+		length time.Duration
+	}{
+		{tr("Day elapsed"), dayStart, dayStart.AddDate(0, 0, 1).Sub(dayStart)},
+		{tr("Week elapsed"), weekStart, weekStart.AddDate(0, 0, 7).Sub(weekStart)},
+	}
+
+	for _, e := range elapsed {
+		percent := fmt.Sprintf("%.1f%%", 100*float64(now.Sub(e.start))/float64(e.length))
+		items.Items = append(items.Items, Item{
+			Title:    e.title,
+			Subtitle: percent,
+			Arg:      percent,
+		})
+	}
+
+	return items
+}
@@ -0,0 +1,285 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Number of runs listed by `cron <expr>`
+const cronRuns = 10
+
+// How far to look for the next run, e.g. `0 0 30 2 *` never runs
+const cronHorizon = 5 * 366 * 24 * time.Hour
+
+// Schedule of a 5 field cron expression, allowed values of each field
+type cronSchedule struct {
+	minute, hour, dom, month, dow map[int]bool
+	// day of month and day of week both restricted, either one matches
+	domOrDow bool
+	loc      *time.Location
+}
+
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+var cronMonths = []string{"jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec"}
+
+var cronWeekdays = []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+
+// `*/15 9-17 * * 1-5`, `@daily`, optionally with `CRON_TZ=<zone>` first
+func parseCron(expr string) (cronSchedule, error) {
+	s := cronSchedule{loc: time.Local}
+
+	fields := strings.Fields(expr)
+	if len(fields) > 0 && (strings.HasPrefix(fields[0], "CRON_TZ=") || strings.HasPrefix(fields[0], "TZ=")) {
+		_, zone, _ := strings.Cut(fields[0], "=")
+		loc, err := time.LoadLocation(zone)
+		if err != nil {
+			return s, fmt.Errorf(tr("unknown time zone %s"), zone)
+		}
+		s.loc = loc
+		fields = fields[1:]
+	}
+
+	if len(fields) == 1 {
+		if macro, ok := cronMacros[strings.ToLower(fields[0])]; ok {
+			fields = strings.Fields(macro)
+		}
+	}
+
+	if len(fields) != 5 {
+		return s, errors.New(tr("cron needs 5 fields: minute hour day month weekday"))
+	}
+
+	var err error
+	if s.minute, err = parseCronField(fields[0], 0, 59, nil); err != nil {
+		return s, err
+	}
+	if s.hour, err = parseCronField(fields[1], 0, 23, nil); err != nil {
+		return s, err
+	}
+	if s.dom, err = parseCronField(fields[2], 1, 31, nil); err != nil {
+		return s, err
+	}
+	if s.month, err = parseCronField(fields[3], 1, 12, cronMonths); err != nil {
+		return s, err
+	}
+	if s.dow, err = parseCronField(fields[4], 0, 7, cronWeekdays); err != nil {
+		return s, err
+	}
+
+	// 7 is Sunday too
+	if s.dow[7] {
+		s.dow[0] = true
+	}
+
+	s.domOrDow = !strings.HasPrefix(fields[2], "*") && !strings.HasPrefix(fields[4], "*")
+	return s, nil
+}
+
+// `*`, `*/n`, `a`, `a-b`, `a-b/n` and lists of them, names if given
+func parseCronField(field string, min, max int, names []string) (map[int]bool, error) {
+	values := map[int]bool{}
+
+	value := func(v string) (int, error) {
+		for i, name := range names {
+			if strings.EqualFold(v, name) {
+				// months are counted from 1, weekdays from 0
+				return i + min, nil
+			}
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil || n < min || n > max {
+			return 0, fmt.Errorf(tr("invalid cron value %s"), v)
+		}
+		return n, nil
+	}
+
+	for _, part := range strings.Split(field, ",") {
+		r, step, found := strings.Cut(part, "/")
+
+		n := 1
+		if found {
+			var err error
+			if n, err = strconv.Atoi(step); err != nil || n < 1 {
+				return nil, fmt.Errorf(tr("invalid cron step %s"), step)
+			}
+		}
+
+		from, to := min, max
+		if r != "*" {
+			a, b, isRange := strings.Cut(r, "-")
+
+			var err error
+			if from, err = value(a); err != nil {
+				return nil, err
+			}
+			to = from
+			if isRange {
+				if to, err = value(b); err != nil {
+					return nil, err
+				}
+			} else if found {
+				// `5/15` is `5-59/15`
+				to = max
+			}
+		}
+
+		if from > to {
+			return nil, fmt.Errorf(tr("invalid cron range %s"), r)
+		}
+		for i := from; i <= to; i += n {
+			values[i] = true
+		}
+	}
+	return values, nil
+}
+
+func (s cronSchedule) matchesDay(t time.Time) bool {
+	if !s.month[int(t.Month())] {
+		return false
+	}
+	if s.domOrDow {
+		return s.dom[t.Day()] || s.dow[int(t.Weekday())]
+	}
+	return s.dom[t.Day()] && s.dow[int(t.Weekday())]
+}
+
+// First run after t
+func (s cronSchedule) next(t time.Time) (time.Time, bool) {
+	t = t.In(s.loc).Truncate(time.Minute).Add(time.Minute)
+	end := t.Add(cronHorizon)
+
+	for t.Before(end) {
+		if !s.matchesDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, s.loc)
+		} else if !s.hour[t.Hour()] {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, s.loc)
+		} else if !s.minute[t.Minute()] {
+			t = t.Add(time.Minute)
+		} else {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// Last run before t
+func (s cronSchedule) previous(t time.Time) (time.Time, bool) {
+	t = t.In(s.loc).Add(-time.Nanosecond).Truncate(time.Minute)
+	end := t.Add(-cronHorizon)
+
+	for t.After(end) {
+		if !s.matchesDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, s.loc).Add(-time.Minute)
+		} else if !s.hour[t.Hour()] {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, s.loc).Add(-time.Minute)
+		} else if !s.minute[t.Minute()] {
+			t = t.Add(-time.Minute)
+		} else {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// Runs after (or before) the anchor, closest first
+func (s cronSchedule) runs(anchor time.Time, before bool, n int) []time.Time {
+	var runs []time.Time
+
+	t := anchor
+	for len(runs) < n {
+		var ok bool
+		if before {
+			t, ok = s.previous(t)
+		} else {
+			t, ok = s.next(t)
+		}
+		if !ok {
+			break
+		}
+		runs = append(runs, t)
+	}
+	return runs
+}
+
+// `cron <expr>`, `cron <expr> after <date>`, `cron <expr> before <date>`
+func cronItems(match []string) Items {
+	s, err := parseCron(match[1])
+	if err != nil {
+		return getItems(datetime{}, err)
+	}
+
+	anchor := timeNow()
+	if match[3] != "" {
+		dt, err := evaluateDate(match[3], loadEnvironment())
+		if err == nil && dt.kind&timestamp == 0 {
+			err = fmt.Errorf(tr("cron %s needs a timestamp"), match[2])
+		}
+		if err != nil {
+			return getItems(datetime{}, err)
+		}
+		anchor = dt.dt
+	}
+
+	before := match[2] == "before"
+	runs := s.runs(anchor, before, cronRuns)
+	if len(runs) == 0 {
+		return getItems(datetime{}, errors.New(tr("cron expression never runs")))
+	}
+
+	items := Items{
+		Skipknowldedge: true,
+	}
+
+	var shortest, longest time.Duration
+	for i, t := range runs {
+		// from the anchor for the first run, from the previous one for others
+		from := anchor
+		if i > 0 {
+			from = runs[i-1]
+		}
+		interval := t.Sub(from).Abs()
+
+		if i > 0 && (shortest == 0 || interval < shortest) {
+			shortest = interval
+		}
+		if i > 0 && interval > longest {
+			longest = interval
+		}
+
+		subtitle := fmt.Sprintf(tr("in %s"), formatRelative(interval))
+		if before {
+			subtitle = fmt.Sprintf(tr("%s earlier"), formatRelative(interval))
+		}
+
+		items.Items = append(items.Items, Item{
+			Title:    formatDate(t) + " " + formatClock(t),
+			Subtitle: t.Format("2006-01-02 15:04:05 MST") + " (" + subtitle + ")",
+			Arg:      t.Format("2006-01-02 15:04:05"),
+		})
+	}
+
+	if len(runs) > 1 {
+		interval := formatRelative(shortest)
+		if longest != shortest {
+			interval += " – " + formatRelative(longest)
+		}
+		items.Items = append(items.Items, Item{
+			Title:    tr("Interval between runs"),
+			Subtitle: interval,
+			Arg:      interval,
+		})
+	}
+	return items
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCron(t *testing.T) {
+	// Friday
+	anchor := time.Date(2024, 11, 22, 18, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		expr     string
+		before   bool
+		expected []time.Time
+	}{
+		{
+			expr: "*/15 9-17 * * 1-5",
+			expected: []time.Time{
+				time.Date(2024, 11, 25, 9, 0, 0, 0, time.Local),
+				time.Date(2024, 11, 25, 9, 15, 0, 0, time.Local),
+			},
+		},
+		{
+			expr: "*/15 9-17 * * 1-5",
+			// 18:00 isn't in 9-17
+			before: true,
+			expected: []time.Time{
+				time.Date(2024, 11, 22, 17, 45, 0, 0, time.Local),
+				time.Date(2024, 11, 22, 17, 30, 0, 0, time.Local),
+			},
+		},
+		{
+			expr: "0 12 1,15 * fri",
+			expected: []time.Time{
+				time.Date(2024, 11, 29, 12, 0, 0, 0, time.Local),
+				time.Date(2024, 12, 1, 12, 0, 0, 0, time.Local),
+			},
+		},
+		{
+			expr: "@monthly",
+			expected: []time.Time{
+				time.Date(2024, 12, 1, 0, 0, 0, 0, time.Local),
+				time.Date(2025, 1, 1, 0, 0, 0, 0, time.Local),
+			},
+		},
+		{
+			expr: "30 4 29 feb *",
+			expected: []time.Time{
+				time.Date(2028, 2, 29, 4, 30, 0, 0, time.Local),
+			},
+		},
+		{
+			expr:     "0 0 30 2 *",
+			expected: nil,
+		},
+	}
+
+	for _, ts := range tests {
+		s, err := parseCron(ts.expr)
+		if err != nil {
+			t.Errorf(">>> Input %s: %v\n", ts.expr, err)
+			continue
+		}
+
+		runs := s.runs(anchor, ts.before, max(len(ts.expected), 1))
+		if len(runs) != len(ts.expected) {
+			t.Errorf(">>> Input %s: expected %v, got %v\n", ts.expr, ts.expected, runs)
+			continue
+		}
+		for i, run := range runs {
+			if !run.Equal(ts.expected[i]) {
+				t.Errorf(">>> Input %s: expected %v, got %v\n", ts.expr, ts.expected[i], run)
+			}
+		}
+	}
+
+	for _, expr := range []string{"* * * *", "61 * * * *", "*/0 * * * *", "5-1 * * * *", "* * * * funday"} {
+		if _, err := parseCron(expr); err == nil {
+			t.Errorf(">>> Expected error for %s\n", expr)
+		}
+	}
+}
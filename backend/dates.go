@@ -0,0 +1,221 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var (
+	dateRegex    = regexp.MustCompile(`^([0-9]{1,2})/([0-9]{1,2})(?:/([0-9]{2}|[0-9]{4}))?$`)
+	isoDateRegex = regexp.MustCompile(`^([0-9]{4})-([0-9]{2})-([0-9]{2})$`)
+)
+
+var weekdays = map[string]time.Weekday{
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+	"sun": time.Sunday,
+}
+
+// Midnight of given day in local time zone
+func midnight(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+}
+
+// Parse `<date>` or `<date> <time>`, where date is any of:
+//   - `<DD>/<MM>`, `<DD>/<MM>/<YYYY>` (or month first, if configured)
+//   - `<YYYY>-<MM>-<DD>`
+//   - `<weekday>`, the next one after today
+//
+// Date without year is in current year.
+func parseDate(f string) (time.Time, bool) {
+	date, clock, _ := strings.Cut(f, " ")
+
+	var year, month, day int64
+	now := timeNow()
+
+	if match := dateRegex.FindStringSubmatch(date); match != nil {
+		day, month = Atoi(match[1]), Atoi(match[2])
+		if dateOrder() == monthFirst {
+			day, month = month, day
+		}
+
+		year = int64(now.Year())
+		if match[3] != "" {
+			year = Atoi(match[3])
+			if len(match[3]) == 2 {
+				year += 2000
+			}
+		}
+	} else if match := isoDateRegex.FindStringSubmatch(date); match != nil {
+		year, month, day = Atoi(match[1]), Atoi(match[2]), Atoi(match[3])
+	} else if wd, ok := parseWeekday(date); ok {
+		t := midnight(now)
+		t = t.AddDate(0, 0, 1)
+		for t.Weekday() != wd {
+			t = t.AddDate(0, 0, 1)
+		}
+		year, month, day = int64(t.Year()), int64(t.Month()), int64(t.Day())
+	} else {
+		return time.Time{}, false
+	}
+
+	// Reject 31/02 and similar, instead of rolling over
+	t := time.Date(int(year), time.Month(month), int(day), 0, 0, 0, 0, time.Local)
+	if t.Day() != int(day) || t.Month() != time.Month(month) {
+		return time.Time{}, false
+	}
+
+	if clock != "" {
+		offset, ok := parseClock(clock)
+		if !ok {
+			return time.Time{}, false
+		}
+		t = t.Add(offset)
+	}
+	return t, true
+}
+
+// `<DD>/<MM>` without a year, in expressions read as a division, e.g. `10/2` is 5
+func isPartialDate(f string) bool {
+	date, _, _ := strings.Cut(f, " ")
+	match := dateRegex.FindStringSubmatch(date)
+	return match != nil && match[3] == ""
+}
+
+// `<n>bd`, `<n>wh` or `<n>wm`, which only go with dates
+var businessFieldRegex = regexp.MustCompile(`^[0-9]+(?:bd|wh|wm)$`)
+
+// `22/11 + 10bd`, `<DD>/<MM>` with business days or working time
+// is a date in the current year, not a division
+func businessDates(p string) string {
+	fields := strings.Fields(p)
+	isOperator := func(i int) bool {
+		return i >= 0 && i < len(fields) && (fields[i] == "+" || fields[i] == "-")
+	}
+	isBusiness := func(i int) bool {
+		return i >= 0 && i < len(fields) && businessFieldRegex.MatchString(fields[i])
+	}
+
+	for i, f := range fields {
+		if _, ok := parseDate(f); !ok || !isPartialDate(f) {
+			continue
+		}
+
+		// `22/11 15:00 + 6wh`
+		next := i + 1
+		if next < len(fields) && isClock(fields[next]) {
+			next++
+		}
+
+		if (isOperator(next) && isBusiness(next+1)) || (isOperator(i-1) && isBusiness(i-2)) {
+			fields[i] = fmt.Sprintf("%s/%d", f, timeNow().Year())
+		}
+	}
+	return strings.Join(fields, " ")
+}
+
+// Date argument of a command, e.g. `until 24/12` or `age 22/11 + 1d`,
+// where `<DD>/<MM>` is a date in the current year, not a division
+func evaluateDate(p string, env environment) (datetime, error) {
+	fields := strings.Fields(p)
+	for i, f := range fields {
+		if _, ok := parseDate(f); ok && isPartialDate(f) {
+			fields[i] = fmt.Sprintf("%s/%d", f, timeNow().Year())
+		}
+	}
+	return evaluate(strings.Join(fields, " "), env)
+}
+
+// `mon`, `monday`, `Mon`, ...
+func parseWeekday(f string) (time.Weekday, bool) {
+	f = strings.ToLower(f)
+	if len(f) < 3 {
+		return 0, false
+	}
+
+	wd, ok := weekdays[f[:3]]
+	if !ok || !strings.HasPrefix(strings.ToLower(wd.String()), f) {
+		return 0, false
+	}
+	return wd, true
+}
+
+var meridiemRegex = regexp.MustCompile(`^(?i)[ap]\.?m\.?$`)
+
+// `<hh:mm>` or `<hh:mm:ss>` as offset from midnight,
+// also 12-hour clock, e.g. `9am`, `9:30pm`, `9:30 PM`
+func parseClock(f string) (time.Duration, bool) {
+	match := clockRegex.FindStringSubmatch(f)
+	if match == nil || (match[2] == "" && match[4] == "") {
+		return 0, false
+	}
+
+	h, m, s := Atoi(match[1]), Atoi(match[2]), Atoi(match[3])
+
+	if match[4] != "" {
+		// 12am is midnight, 12pm is noon
+		if h < 1 || h > 12 {
+			return 0, false
+		}
+		h %= 12
+		if strings.EqualFold(match[4], "p") {
+			h += 12
+		}
+	}
+
+	if h > 23 || m > 59 || s > 59 {
+		return 0, false
+	}
+	return time.Duration(h)*time.Hour + time.Duration(m)*time.Minute + time.Duration(s)*time.Second, true
+}
+
+// Like AddDate, but day past the end of month is the last day,
+// i.e. 31/01 + 1 month is 29/02, not 02/03
+func addMonths(t time.Time, years int, months int) time.Time {
+	first := time.Date(t.Year()+years, t.Month()+time.Month(months), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	last := first.AddDate(0, 1, -1).Day()
+
+	day := t.Day()
+	if day > last {
+		day = last
+	}
+	return first.AddDate(0, 0, day-1)
+}
+
+// Calendar-correct span between two timestamps, e.g. 36 years, 4 months and 4 days
+// year, month and day of the result are calendar components,
+// ts and the rest are the same as for any duration
+func calendarSpan(from, to time.Time) datetime {
+	sign := int64(1)
+	if to.Before(from) {
+		from, to = to, from
+		sign = -1
+	}
+
+	years := to.Year() - from.Year()
+	if addMonths(from, years, 0).After(to) {
+		years--
+	}
+
+	months := 0
+	for !addMonths(from, years, months+1).After(to) {
+		months++
+	}
+
+	rest := int64(to.Sub(addMonths(from, years, months)).Seconds())
+
+	dt := newDuration(sign * int64(to.Sub(from).Seconds()))
+	dt.year = sign * int64(years)
+	dt.month = sign * int64(months)
+	dt.day = sign * (rest / (24 * 3600))
+	dt.hour = sign * (rest % (24 * 3600) / 3600)
+	dt.minute = sign * (rest % 3600 / 60)
+	dt.second = sign * (rest % 60)
+	return dt
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Go, Prometheus and systemd durations, e.g. `1h2m3.5s`, `350ms`, `1w2d`
+var durationDialectRegex = regexp.MustCompile(`^(?:[0-9]+(?:\.[0-9]+)?(?:ms|us|µs|ns|y|w|d|h|m|s))+$`)
+
+var durationComponentRegex = regexp.MustCompile(`([0-9]+(?:\.[0-9]+)?)(ms|us|µs|ns|y|w|d|h|m|s)`)
+
+var durationUnits = map[string]time.Duration{
+	"y":  365 * 24 * time.Hour, // as in Prometheus
+	"w":  7 * 24 * time.Hour,
+	"d":  24 * time.Hour,
+	"h":  time.Hour,
+	"m":  time.Minute,
+	"s":  time.Second,
+	"ms": time.Millisecond,
+	"us": time.Microsecond,
+	"µs": time.Microsecond,
+	"ns": time.Nanosecond,
+}
+
+// systemd style `2 weeks`, `1min`, `30 sec` -> `2w`, `1m`, `30s`
+var durationWordsRegex = regexp.MustCompile(`(?i)\b([0-9]+(?:\.[0-9]+)?) ?(years?|weeks?|days?|hours?|hrs?|minutes?|mins?|seconds?|secs?|milliseconds?|msecs?|microseconds?|usecs?|nanoseconds?|nsecs?)\b`)
+
+var durationWords = map[string]string{
+	"year": "y", "week": "w", "day": "d", "hour": "h", "hr": "h",
+	"minute": "m", "min": "m", "second": "s", "sec": "s",
+	"millisecond": "ms", "msec": "ms",
+	"microsecond": "us", "usec": "us",
+	"nanosecond": "ns", "nsec": "ns",
+}
+
+func normalizeDurationWords(p string) string {
+	return durationWordsRegex.ReplaceAllStringFunc(p, func(w string) string {
+		match := durationWordsRegex.FindStringSubmatch(w)
+		unit := strings.TrimSuffix(strings.ToLower(match[2]), "s")
+		if short, ok := durationWords[unit]; ok {
+			return match[1] + short
+		}
+		return w
+	})
+}
+
+// Components of b continue those of a with smaller units,
+// e.g. `1m` and `30s`, but not `1h` and `2h`
+func unitsDescend(a string, b string) bool {
+	first := durationComponentRegex.FindAllStringSubmatch(a, -1)
+	second := durationComponentRegex.FindAllStringSubmatch(b, -1)
+	if len(first) == 0 || len(second) == 0 {
+		return false
+	}
+	return durationUnits[first[len(first)-1][2]] > durationUnits[second[0][2]]
+}
+
+// Seconds and nanoseconds of a duration in any of the dialects
+func parseDurationDialect(f string) (int64, int64, bool) {
+	var total float64 // in nanoseconds
+
+	for _, match := range durationComponentRegex.FindAllStringSubmatch(f, -1) {
+		n, err := strconv.ParseFloat(match[1], 64)
+		if err != nil {
+			return 0, 0, false
+		}
+		total += n * float64(durationUnits[match[2]])
+	}
+
+	if total > math.MaxInt64 {
+		return 0, 0, false
+	}
+	ns := int64(math.Round(total))
+	return ns / int64(time.Second), ns % int64(time.Second), true
+}
+
+// Duration in nanoseconds, includes the sub-second part
+func (dt datetime) nanoseconds() time.Duration {
+	return time.Duration(dt.ts)*time.Second + time.Duration(dt.ns)
+}
+
+// Carry nanoseconds over to seconds, both with the same sign
+func (dt *datetime) normalizeNanoseconds() {
+	dt.ts += dt.ns / int64(time.Second)
+	dt.ns %= int64(time.Second)
+
+	if dt.ts > 0 && dt.ns < 0 {
+		dt.ts--
+		dt.ns += int64(time.Second)
+	} else if dt.ts < 0 && dt.ns > 0 {
+		dt.ts++
+		dt.ns -= int64(time.Second)
+	}
+}
+
+// `1d2h3m4s500ms`, largest unit first, zeros omitted
+func formatPrometheus(d time.Duration) string {
+	if d == 0 {
+		return "0s"
+	}
+
+	var b strings.Builder
+	if d < 0 {
+		b.WriteString("-")
+		d = -d
+	}
+
+	for _, unit := range []string{"y", "w", "d", "h", "m", "s", "ms"} {
+		if n := d / durationUnits[unit]; n > 0 {
+			fmt.Fprintf(&b, "%d%s", n, unit)
+			d -= n * durationUnits[unit]
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,216 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Unix epoch units, by suffix of `<n>u`
+var epochUnits = map[string]time.Duration{
+	"u":   time.Second,
+	"ums": time.Millisecond,
+	"uus": time.Microsecond,
+	"uµs": time.Microsecond,
+	"uns": time.Nanosecond,
+}
+
+// Output formats of Unix epoch units
+var epochUnitFormats = map[time.Duration]string{
+	time.Second:      "u",
+	time.Millisecond: "ums",
+	time.Microsecond: "uus",
+	time.Nanosecond:  "uns",
+}
+
+var epochUnitNames = map[time.Duration]string{
+	time.Second:      "seconds",
+	time.Millisecond: "milliseconds",
+	time.Microsecond: "microseconds",
+	time.Nanosecond:  "nanoseconds",
+}
+
+// Unit of `<n>u` guessed from the number of digits,
+// e.g. 13 digits of JavaScript Date.now() are milliseconds
+func guessEpochUnit(digits string) time.Duration {
+	switch n := len(digits); {
+	case n <= 11:
+		return time.Second
+	case n <= 14:
+		return time.Millisecond
+	case n <= 17:
+		return time.Microsecond
+	}
+	return time.Nanosecond
+}
+
+// `<n>u` (seconds, or guessed from digits), `<n>ums`, `<n>uus`, `<n>uns`
+func parseEpoch(digits string, suffix string, dt *datetime) {
+	unit, ok := epochUnits[suffix]
+	if !ok {
+		return
+	}
+
+	if suffix == "u" {
+		unit = guessEpochUnit(digits)
+	}
+
+	// out of range, rather than 1970
+	n, err := strconv.ParseInt(digits, 10, 64)
+	if err != nil || n > int64(1<<63-1)/int64(unit) {
+		return
+	}
+
+	if suffix == "u" && unit != time.Second {
+		dt.note = fmt.Sprintf(tr("%d digits, read as %s"), len(digits), tr(epochUnitNames[unit]))
+	}
+	dt.dt = time.Unix(0, n*int64(unit))
+	dt.kind = timestamp
+	dt.epoch = epochUnitFormats[unit]
+}
+
+// Unix epoch of the timestamp in given unit
+func formatEpoch(t time.Time, unit time.Duration) string {
+	if unit == time.Second {
+		return fmt.Sprintf("%d", t.Unix())
+	}
+	return fmt.Sprintf("%d", t.UnixNano()/int64(unit))
+}
+
+// Other epoch systems, `<n><suffix>`
+type epochSystem struct {
+	title    string
+	suffixes []string
+	names    []string // for `as`, in addition to suffixes
+	origin   time.Time
+	unit     time.Duration // 0 for fractional days
+	offset   int64         // seconds ahead of UTC
+}
+
+var epochSystems = []epochSystem{
+	{
+		// also LDAP / Active Directory timestamps
+		title:    "Windows FILETIME",
+		suffixes: []string{"ft", "ldap"},
+		names:    []string{"filetime"},
+		origin:   time.Date(1601, 1, 1, 0, 0, 0, 0, time.UTC),
+		unit:     100 * time.Nanosecond,
+	},
+	{
+		title:    ".NET ticks",
+		suffixes: []string{"ticks"},
+		names:    []string{"dotnet"},
+		origin:   time.Date(1, 1, 1, 0, 0, 0, 0, time.UTC),
+		unit:     100 * time.Nanosecond,
+	},
+	{
+		title:    "Cocoa",
+		suffixes: []string{"cocoa"},
+		names:    []string{"apple"},
+		origin:   time.Date(2001, 1, 1, 0, 0, 0, 0, time.UTC),
+		unit:     time.Second,
+	},
+	{
+		// Local date & time, 1900 date system
+		title:    "Excel serial",
+		suffixes: []string{"xl", "lotus"},
+		names:    []string{"excel"},
+		origin:   time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC),
+	},
+	{
+		// GPS time doesn't count leap seconds, 18 of them since 1980
+		title:    "GPS",
+		suffixes: []string{"gps"},
+		origin:   time.Date(1980, 1, 6, 0, 0, 0, 0, time.UTC),
+		unit:     time.Second,
+		offset:   18,
+	},
+	{
+		title:    "WebKit",
+		suffixes: []string{"webkit", "chrome"},
+		origin:   time.Date(1601, 1, 1, 0, 0, 0, 0, time.UTC),
+		unit:     time.Microsecond,
+	},
+	{
+		title:    "HFS+",
+		suffixes: []string{"hfs"},
+		names:    []string{"hfsplus"},
+		origin:   time.Date(1904, 1, 1, 0, 0, 0, 0, time.UTC),
+		unit:     time.Second,
+	},
+}
+
+func findEpochSystem(suffix string) (epochSystem, bool) {
+	for _, e := range epochSystems {
+		for _, s := range e.suffixes {
+			if strings.EqualFold(s, suffix) {
+				return e, true
+			}
+		}
+	}
+	return epochSystem{}, false
+}
+
+// Timestamp of `n` units since origin of the epoch system
+func (e epochSystem) time(n string) (time.Time, bool) {
+	if e.unit == 0 {
+		days, err := strconv.ParseFloat(n, 64)
+		if err != nil || days > 2958465 {
+			return time.Time{}, false
+		}
+
+		// Lotus 1-2-3 counted 29/02/1900, Excel kept it for compatibility
+		origin := e.origin
+		if days < 61 {
+			origin = origin.AddDate(0, 0, 1)
+		}
+
+		whole, fraction := math.Modf(days)
+		t := origin.AddDate(0, 0, int(whole)).Add(time.Duration(math.Round(fraction*24*3600)) * time.Second)
+		return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.Local), true
+	}
+
+	if strings.Contains(n, ".") {
+		return time.Time{}, false
+	}
+
+	perSecond := int64(time.Second / e.unit)
+	i := Atoi(n)
+	seconds, rest := i/perSecond, i%perSecond
+	return time.Unix(e.origin.Unix()+seconds-e.offset, rest*int64(e.unit)), true
+}
+
+// Timestamp as units since origin of the epoch system
+func (e epochSystem) format(t time.Time) string {
+	if e.unit == 0 {
+		naive := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.UTC)
+		days := naive.Sub(e.origin).Hours() / 24
+		if days < 61 {
+			days--
+		}
+		return strconv.FormatFloat(math.Round(days*1e5)/1e5, 'f', -1, 64)
+	}
+
+	perSecond := int64(time.Second / e.unit)
+	seconds := t.Unix() - e.origin.Unix() + e.offset
+	return fmt.Sprintf("%d", seconds*perSecond+int64(t.Nanosecond())/int64(e.unit))
+}
+
+// Hidden output items, e.g. `now as ft`
+func epochOutputItemFormats() []outputItemFormat {
+	var formats []outputItemFormat
+
+	for _, e := range epochSystems {
+		formats = append(formats, outputItemFormat{
+			title:  e.title,
+			names:  append(e.names, e.suffixes...),
+			hidden: true,
+			formatFunc: func(dt datetime) string {
+				return e.format(dt.dt)
+			},
+		})
+	}
+	return formats
+}
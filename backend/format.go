@@ -0,0 +1,119 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Digit grouping and decimal separator, NUMBER_FORMAT variable
+func numberSeparators() (group string, decimal string) {
+	switch getConfig("NUMBER_FORMAT", "1") {
+	case "2":
+		return ".", ","
+	case "3":
+		return " ", ","
+	case "4":
+		return "'", "."
+	case "5":
+		return "", "."
+	}
+	return ",", "."
+}
+
+// 24-hour or 12-hour clock, CLOCK_FORMAT variable
+func twelveHourClock() bool {
+	return getConfig("CLOCK_FORMAT", "24") == "12"
+}
+
+// `18:15:00` or `6:15 PM`, seconds shown on 12-hour clock only if set
+func formatClock(t time.Time) string {
+	if !twelveHourClock() {
+		return t.Format("15:04:05")
+	} else if t.Second() != 0 {
+		return t.Format("3:04:05 PM")
+	}
+	return t.Format("3:04 PM")
+}
+
+// 1234567 -> 1,234,567
+func formatInt(n int64) string {
+	return formatFloat(float64(n), 0)
+}
+
+// 1234567.891 -> 1,234,567.89
+func formatFloat(f float64, decimals int) string {
+	group, decimal := numberSeparators()
+
+	s := strconv.FormatFloat(f, 'f', decimals, 64)
+	sign := ""
+	if strings.HasPrefix(s, "-") {
+		sign, s = "-", s[1:]
+	}
+
+	whole, fraction, _ := strings.Cut(s, ".")
+
+	var b strings.Builder
+	for i, c := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			b.WriteString(group)
+		}
+		b.WriteRune(c)
+	}
+
+	if fraction != "" {
+		return sign + b.String() + decimal + fraction
+	}
+	return sign + b.String()
+}
+
+// `1 day`, `1,234 days`
+func formatUnit(n int64, unit string) string {
+	return formatInt(n) + " " + unitName(unit, float64(n), false)
+}
+
+// `1.50 days`
+func formatUnitFloat(f float64, decimals int, unit string) string {
+	return formatFloat(f, decimals) + " " + unitName(unit, f, decimals > 0)
+}
+
+// `7200 seconds`, copied without digit grouping
+func plainUnit(n int64, unit string) string {
+	return strconv.FormatInt(n, 10) + " " + unitName(unit, float64(n), false)
+}
+
+// `4560.00 minutes`
+func plainUnitFloat(f float64, decimals int, unit string) string {
+	_, decimal := numberSeparators()
+	s := strings.Replace(strconv.FormatFloat(f, 'f', decimals, 64), ".", decimal, 1)
+	return s + " " + unitName(unit, f, decimals > 0)
+}
+
+// `a`, `a and b`, `a, b and c`
+func joinList(parts []string) string {
+	if len(parts) <= 1 {
+		return strings.Join(parts, "")
+	}
+	return strings.Join(parts[:len(parts)-1], ", ") + " " + languages[language()].and + " " + parts[len(parts)-1]
+}
+
+// `1 day, 0 hours, 0 minutes and 12 seconds`,
+// or `1 day and 12 seconds` if OMIT_ZERO is set
+// values & units are from the largest, e.g. days, hours, minutes, seconds
+func formatComponents(values []int64, units []string) string {
+	omitZero := getConfig("OMIT_ZERO", "0") == "1"
+
+	var parts []string
+	for i, v := range values {
+		if v == 0 && omitZero {
+			continue
+		}
+		parts = append(parts, formatUnit(v, units[i]))
+	}
+
+	// nothing left, show the smallest unit
+	if len(parts) == 0 {
+		parts = append(parts, formatUnit(0, units[len(units)-1]))
+	}
+	return joinList(parts)
+}
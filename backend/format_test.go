@@ -0,0 +1,159 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		numberFormat string
+		omitZero     string
+		input        string
+		expected     []string
+	}{
+		{
+			input:    "1d12s",
+			expected: []string{"1 day, 0 hours, 0 minutes and 12 seconds", "1d, 00:00:12", "1.00 days", "24.00 hours", "1,440.20 minutes", "86,412 seconds"},
+		},
+		{
+			omitZero: "1",
+			input:    "1d12s",
+			expected: []string{"1 day and 12 seconds"},
+		},
+		{
+			omitZero: "1",
+			input:    "1h - 1h",
+			expected: []string{"0 seconds"},
+		},
+		{
+			numberFormat: "2",
+			input:        "2469134 / 2",
+			expected:     []string{"1.234.567"},
+		},
+		{
+			numberFormat: "3",
+			input:        "10000h * 99.5/h",
+			expected:     []string{"995 000,00"},
+		},
+		{
+			numberFormat: "5",
+			input:        "90000s in minutes",
+			expected:     []string{"1500.00 minutes"},
+		},
+		{
+			input:    "1h - 1d2h3m4s",
+			expected: []string{"-1 day, 1 hour, 3 minutes and 4 seconds", "-1d, 01:03:04"},
+		},
+		{
+			input:    "01/03/2024 - 01/03/2024 14:10:07",
+			expected: []string{"-0 days, 14 hours, 10 minutes and 7 seconds", "-14:10:07"},
+		},
+		{
+			input:    "3bd - 2bd",
+			expected: []string{"1 business day"},
+		},
+	}
+
+	for _, ts := range tests {
+		t.Setenv("NUMBER_FORMAT", ts.numberFormat)
+		t.Setenv("OMIT_ZERO", ts.omitZero)
+
+		dt, err := evaluate(ts.input, nil)
+		items := resultItems(dt)
+
+		for i, expected := range ts.expected {
+			if err != nil || i >= len(items) || items[i].Subtitle != expected {
+				t.Error(">>> Input", ts.input, ts.numberFormat, ts.omitZero)
+				t.Errorf(">>> Expected %s\n", expected)
+				t.Errorf(">>> Result   %+v %v\n", items, err)
+				break
+			}
+		}
+	}
+
+	// copied values aren't grouped
+	t.Setenv("NUMBER_FORMAT", "")
+	for input, expected := range map[string]string{
+		"2469134 / 2":        "1234567",
+		"10000h * 99.5/h":    "995000.00",
+		"2h in seconds":      "7200 seconds",
+		"3d4h in minutes":    "4560.00 minutes",
+		"1500.5s in seconds": "1500.500 seconds",
+	} {
+		dt, _ := evaluate(input, nil)
+		if items := resultItems(dt); items[0].Arg != expected {
+			t.Errorf(">>> Expected %s copied for %s, got %s\n", expected, input, items[0].Arg)
+		}
+	}
+
+	// and shown grouped
+	t.Setenv("NUMBER_FORMAT", "2")
+	dt, _ := evaluate("90000s in minutes", nil)
+	if item := resultItems(dt)[0]; item.Subtitle != "1.500,00 minutes" || item.Arg != "1500,00 minutes" {
+		t.Errorf(">>> Unexpected %+v\n", item)
+	}
+}
+
+func TestLanguages(t *testing.T) {
+	var tests = []struct {
+		language string
+		input    string
+		title    string
+		expected string
+	}{
+		{"pl", "1d", "Wynik", "1 dzień"},
+		{"pl", "3d2h", "Wynik", "3 dni i 2 godziny"},
+		{"pl", "5h22m", "Wynik", "5 godzin i 22 minuty"},
+		{"pl", "12h13m", "Wynik", "12 godzin i 13 minut"},
+		{"pl", "36h in days", "W dniach", "1.50 dnia"},
+		{"de", "1d1h", "Ergebnis", "1 Tag und 1 Stunde"},
+		{"fr", "1d2h", "Résultat", "1 jour et 2 heures"},
+		{"fr", "36h in days", "En jours", "1.50 jour"},
+		{"es", "2d1m", "Resultado", "2 días y 1 minuto"},
+		{"xx", "2d1m", "Result", "2 days and 1 minute"},
+		{"de", "02/03/2024 in full", "Vollständiges Datum", "Samstag, 2. März 2024 00:00:00"},
+		{"pl", "02/03/2024 in full", "Pełna data", "sobota, 2 marca 2024 00:00:00"},
+		{"es", "02/03/2024 in full", "Fecha completa", "sábado, 2 de marzo de 2024 00:00:00"},
+	}
+
+	for _, ts := range tests {
+		t.Setenv("LANGUAGE", ts.language)
+		t.Setenv("NUMBER_FORMAT", "")
+		t.Setenv("OMIT_ZERO", "1")
+		t.Setenv("DATE_FORMAT", "")
+
+		dt, err := evaluate(ts.input, nil)
+		items := resultItems(dt)
+
+		if err != nil || len(items) == 0 || items[0].Title != ts.title || items[0].Subtitle != ts.expected {
+			t.Error(">>> Input", ts.input, ts.language)
+			t.Errorf(">>> Expected %s: %s\n", ts.title, ts.expected)
+			t.Errorf(">>> Result   %+v %v\n", items, err)
+		}
+	}
+}
+
+func TestLanguageErrors(t *testing.T) {
+	var tests = []struct {
+		language string
+		input    string
+		expected string
+	}{
+		{"de", "foo", "unbekannte Variable foo"},
+		{"pl", "now * 2", "nie można obliczyć now * 2"},
+		{"fr", "10bd / 0", "division par zéro"},
+		{"xx", "foo", "unknown variable foo"},
+	}
+
+	for _, ts := range tests {
+		t.Setenv("LANGUAGE", ts.language)
+
+		_, err := evaluate(ts.input, nil)
+
+		if err == nil || err.Error() != ts.expected {
+			t.Error(">>> Input", ts.input, ts.language)
+			t.Errorf(">>> Expected %s\n", ts.expected)
+			t.Errorf(">>> Result   %v\n", err)
+		}
+	}
+}
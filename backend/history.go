@@ -0,0 +1,93 @@
+package main
+
+import (
+	"strings"
+	"time"
+)
+
+const (
+	historyFile = "history.json"
+	historySize = 20
+
+	// Item variable carrying the query, so it can be recorded once actioned
+	historyVariable = "tc_query"
+)
+
+// Successful calculation, most recent first in history.json
+type historyEntry struct {
+	Query  string    `json:"query"`
+	Result string    `json:"result"`
+	Kind   int       `json:"kind"`
+	Time   time.Time `json:"time"`
+}
+
+func loadHistory() ([]historyEntry, error) {
+	var history []historyEntry
+	err := loadState(historyFile, &history)
+	return history, err
+}
+
+// Add query to history, dropping previous runs of the same query
+// and anything above historySize
+func recordHistory(query string) error {
+	query = strings.Join(strings.Fields(query), " ")
+
+	dt, err := evaluate(query, loadEnvironment())
+	if err != nil {
+		return err
+	}
+
+	history, err := loadHistory()
+	if err != nil {
+		return err
+	}
+
+	entry := historyEntry{
+		Query:  query,
+		Result: formatResult(dt),
+		Kind:   dt.kind,
+		Time:   timeNow(),
+	}
+
+	pruned := []historyEntry{entry}
+	for _, h := range history {
+		if h.Query != query && len(pruned) < historySize {
+			pruned = append(pruned, h)
+		}
+	}
+
+	return saveState(historyFile, pruned)
+}
+
+func clearHistory() error {
+	return saveState(historyFile, []historyEntry{})
+}
+
+// Recent calculations, selecting one puts the query back into Alfred
+func historyItems() (Items, bool) {
+	history, err := loadHistory()
+	if err != nil || len(history) == 0 {
+		return Items{}, false
+	}
+
+	items := Items{
+		Skipknowldedge: true,
+	}
+
+	for _, h := range history {
+		items.Items = append(items.Items, Item{
+			Title:        h.Query,
+			Subtitle:     h.Result + " (" + h.Time.Format("2006-01-02 15:04") + ")",
+			Arg:          h.Query,
+			Autocomplete: h.Query,
+			Valid:        notValid(),
+		})
+	}
+
+	items.Items = append(items.Items, Item{
+		Title:    tr("Clear history"),
+		Subtitle: tr("Press Enter to remove all calculations from history"),
+		Arg:      actionPrefix + "clear history",
+	})
+	return items, true
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestRecordHistory(t *testing.T) {
+	t.Setenv("alfred_workflow_data", t.TempDir())
+
+	for i := 0; i < historySize+5; i++ {
+		if err := recordHistory(fmt.Sprintf("%dm", i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	recordHistory("3m")
+	recordHistory("1h +")
+
+	history, _ := loadHistory()
+
+	if len(history) != historySize {
+		t.Errorf(">>> Expected %d entries, got %d\n", historySize, len(history))
+	}
+
+	if history[0].Query != "3m" || history[0].Result != formatResult(newDuration(180)) || history[0].Kind != duration {
+		t.Errorf(">>> Expected 3m on top, got %+v\n", history[0])
+	}
+
+	for _, h := range history[1:] {
+		if h.Query == "3m" {
+			t.Errorf(">>> Duplicated entry %+v\n", h)
+		}
+	}
+
+	recordHistory("24/12/2024")
+	if history, _ := loadHistory(); history[0].Kind != timestamp {
+		t.Errorf(">>> Expected a timestamp on top, got %+v\n", history[0])
+	}
+
+	clearHistory()
+	if _, ok := historyItems(); ok {
+		t.Error(">>> Expected empty history after clear")
+	}
+}
@@ -0,0 +1,406 @@
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const holidaysDir = "holidays"
+
+// Rule generating a holiday for a given year
+type holidayRule struct {
+	name string
+
+	// Fixed date
+	month time.Month
+	day   int
+
+	// Relative to Easter Sunday, used when month is 0
+	easter int
+
+	// n-th weekday of the month, used when weekday is set, -1 is the last one
+	n       int
+	weekday time.Weekday
+
+	// First year the holiday applies, 0 if always
+	since int
+}
+
+func fixed(month time.Month, day int, name string) holidayRule {
+	return holidayRule{name: name, month: month, day: day}
+}
+
+func easter(offset int, name string) holidayRule {
+	return holidayRule{name: name, easter: offset}
+}
+
+func nthWeekday(n int, weekday time.Weekday, month time.Month, name string) holidayRule {
+	return holidayRule{name: name, month: month, n: n, weekday: weekday}
+}
+
+// Public holidays, without substitute days for holidays falling on a weekend
+var holidayRules = map[string][]holidayRule{
+	"PL": {
+		fixed(time.January, 1, "New Year's Day"),
+		fixed(time.January, 6, "Epiphany"),
+		easter(0, "Easter Sunday"),
+		easter(1, "Easter Monday"),
+		fixed(time.May, 1, "Labour Day"),
+		fixed(time.May, 3, "Constitution Day"),
+		easter(49, "Pentecost"),
+		easter(60, "Corpus Christi"),
+		fixed(time.August, 15, "Assumption Day"),
+		fixed(time.November, 1, "All Saints' Day"),
+		fixed(time.November, 11, "Independence Day"),
+		{name: "Christmas Eve", month: time.December, day: 24, since: 2025},
+		fixed(time.December, 25, "Christmas Day"),
+		fixed(time.December, 26, "Second Day of Christmas"),
+	},
+	"DE": {
+		fixed(time.January, 1, "New Year's Day"),
+		easter(-2, "Good Friday"),
+		easter(1, "Easter Monday"),
+		fixed(time.May, 1, "Labour Day"),
+		easter(39, "Ascension Day"),
+		easter(50, "Whit Monday"),
+		fixed(time.October, 3, "German Unity Day"),
+		fixed(time.December, 25, "Christmas Day"),
+		fixed(time.December, 26, "Second Day of Christmas"),
+	},
+	"FR": {
+		fixed(time.January, 1, "New Year's Day"),
+		easter(1, "Easter Monday"),
+		fixed(time.May, 1, "Labour Day"),
+		fixed(time.May, 8, "Victory in Europe Day"),
+		easter(39, "Ascension Day"),
+		easter(50, "Whit Monday"),
+		fixed(time.July, 14, "Bastille Day"),
+		fixed(time.August, 15, "Assumption Day"),
+		fixed(time.November, 1, "All Saints' Day"),
+		fixed(time.November, 11, "Armistice Day"),
+		fixed(time.December, 25, "Christmas Day"),
+	},
+	"ES": {
+		fixed(time.January, 1, "New Year's Day"),
+		fixed(time.January, 6, "Epiphany"),
+		easter(-2, "Good Friday"),
+		fixed(time.May, 1, "Labour Day"),
+		fixed(time.August, 15, "Assumption Day"),
+		fixed(time.October, 12, "National Day"),
+		fixed(time.November, 1, "All Saints' Day"),
+		fixed(time.December, 6, "Constitution Day"),
+		fixed(time.December, 8, "Immaculate Conception"),
+		fixed(time.December, 25, "Christmas Day"),
+	},
+	"GB": {
+		fixed(time.January, 1, "New Year's Day"),
+		easter(-2, "Good Friday"),
+		easter(1, "Easter Monday"),
+		nthWeekday(1, time.Monday, time.May, "Early May Bank Holiday"),
+		nthWeekday(-1, time.Monday, time.May, "Spring Bank Holiday"),
+		nthWeekday(-1, time.Monday, time.August, "Summer Bank Holiday"),
+		fixed(time.December, 25, "Christmas Day"),
+		fixed(time.December, 26, "Boxing Day"),
+	},
+	"US": {
+		fixed(time.January, 1, "New Year's Day"),
+		nthWeekday(3, time.Monday, time.January, "Martin Luther King Jr. Day"),
+		nthWeekday(3, time.Monday, time.February, "Presidents' Day"),
+		nthWeekday(-1, time.Monday, time.May, "Memorial Day"),
+		{name: "Juneteenth", month: time.June, day: 19, since: 2021},
+		fixed(time.July, 4, "Independence Day"),
+		nthWeekday(1, time.Monday, time.September, "Labor Day"),
+		nthWeekday(2, time.Monday, time.October, "Columbus Day"),
+		fixed(time.November, 11, "Veterans Day"),
+		nthWeekday(4, time.Thursday, time.November, "Thanksgiving Day"),
+		fixed(time.December, 25, "Christmas Day"),
+	},
+}
+
+// Easter Sunday, Anonymous Gregorian algorithm
+func easterSunday(year int) time.Time {
+	a := year % 19
+	b, c := year/100, year%100
+	d, e := b/4, b%4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i, k := c/4, c%4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.Local)
+}
+
+func (r holidayRule) date(year int) (time.Time, bool) {
+	if year < r.since {
+		return time.Time{}, false
+	}
+
+	if r.month == 0 {
+		return easterSunday(year).AddDate(0, 0, r.easter), true
+	} else if r.n == 0 {
+		return time.Date(year, r.month, r.day, 0, 0, 0, 0, time.Local), true
+	}
+
+	t := time.Date(year, r.month, 1, 0, 0, 0, 0, time.Local)
+	if r.n < 0 {
+		// last day of the month, going back
+		t = t.AddDate(0, 1, -1)
+		for t.Weekday() != r.weekday {
+			t = t.AddDate(0, 0, -1)
+		}
+		return t, true
+	}
+
+	for t.Weekday() != r.weekday {
+		t = t.AddDate(0, 0, 1)
+	}
+	return t.AddDate(0, 0, 7*(r.n-1)), true
+}
+
+// Holidays from configured countries and files,
+// generated for a year when it's needed first
+type holidayCalendar struct {
+	rules  []holidayRule
+	dates  map[string]string // 2006-01-02 -> name
+	yearly map[string]string // 01-02 -> name, dates without year in files
+	years  map[int]bool
+	err    error // first file which couldn't be loaded
+}
+
+// Loaded once per HOLIDAYS setting and data directory
+var (
+	holidaysLoaded    *holidayCalendar
+	holidaysLoadedFor string
+)
+
+func holidays() *holidayCalendar {
+	key := getConfig("HOLIDAYS", "") + "|" + os.Getenv("alfred_workflow_data")
+	if holidaysLoaded != nil && holidaysLoadedFor == key {
+		return holidaysLoaded
+	}
+
+	c := &holidayCalendar{
+		dates:  map[string]string{},
+		yearly: map[string]string{},
+		years:  map[int]bool{},
+	}
+
+	for _, country := range strings.Split(getConfig("HOLIDAYS", ""), ",") {
+		c.rules = append(c.rules, holidayRules[strings.ToUpper(strings.TrimSpace(country))]...)
+	}
+
+	if dir, err := dataDir(); err == nil {
+		files, _ := filepath.Glob(filepath.Join(dir, holidaysDir, "*"))
+		for _, file := range files {
+			if err := c.loadFile(file); err != nil && c.err == nil {
+				c.err = fmt.Errorf(tr("holidays file %s: %v"), filepath.Base(file), err)
+			}
+		}
+	}
+
+	holidaysLoaded, holidaysLoadedFor = c, key
+	return c
+}
+
+// Name of the holiday on the given day, if any
+func (c *holidayCalendar) holiday(t time.Time) (string, bool) {
+	if !c.years[t.Year()] {
+		c.years[t.Year()] = true
+		for _, r := range c.rules {
+			if d, ok := r.date(t.Year()); ok {
+				c.add(d, r.name)
+			}
+		}
+	}
+
+	if name, ok := c.dates[t.Format("2006-01-02")]; ok {
+		return name, true
+	}
+	name, ok := c.yearly[t.Format("01-02")]
+	return name, ok
+}
+
+func (c *holidayCalendar) add(t time.Time, name string) {
+	key := t.Format("2006-01-02")
+	if existing, ok := c.dates[key]; ok && existing != name {
+		name = existing + ", " + name
+	}
+	c.dates[key] = name
+}
+
+func (c *holidayCalendar) loadFile(file string) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	switch strings.ToLower(filepath.Ext(file)) {
+	case ".ics":
+		return c.loadICS(f)
+	case ".csv":
+		return c.loadCSV(f)
+	}
+	return nil
+}
+
+// `<date>,<name>` per line, where date is `<YYYY>-<MM>-<DD>` or as configured for input
+// Dates without year repeat every year, the first line may be a header
+func (c *holidayCalendar) loadCSV(r io.Reader) error {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return err
+	}
+
+	for i, record := range records {
+		if len(record) == 0 {
+			continue
+		}
+
+		name := "Holiday"
+		if len(record) > 1 {
+			name = record[1]
+		}
+
+		// only fixed dates, not weekdays or times
+		date := strings.TrimSpace(record[0])
+		t, ok := parseDate(date)
+		if !dateRegex.MatchString(date) && !isoDateRegex.MatchString(date) {
+			ok = false
+		}
+		if !ok {
+			if i == 0 {
+				continue
+			}
+			return fmt.Errorf(tr("line %d: %q is not a date"), i+1, date)
+		}
+
+		if match := dateRegex.FindStringSubmatch(date); match != nil && match[3] == "" {
+			c.yearly[t.Format("01-02")] = name
+		} else {
+			c.add(t, name)
+		}
+	}
+	return nil
+}
+
+// VEVENTs with DTSTART, optional DTEND (exclusive) and RRULE:FREQ=YEARLY
+func (c *holidayCalendar) loadICS(r io.Reader) error {
+	var lines []string
+
+	// unfold lines, continuation starts with a space or tab
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if len(lines) > 0 && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+
+	var start, end time.Time
+	var name string
+	var yearly bool
+
+	for _, line := range lines {
+		key, value, _ := strings.Cut(line, ":")
+		key, _, _ = strings.Cut(key, ";")
+
+		switch strings.ToUpper(key) {
+		case "BEGIN":
+			start, end, name, yearly = time.Time{}, time.Time{}, "Holiday", false
+		case "DTSTART":
+			start, _ = parseICSDate(value)
+		case "DTEND":
+			end, _ = parseICSDate(value)
+		case "SUMMARY":
+			name = strings.ReplaceAll(value, `\,`, ",")
+		case "RRULE":
+			yearly = strings.Contains(strings.ToUpper(value), "FREQ=YEARLY")
+		case "END":
+			if strings.ToUpper(value) != "VEVENT" || start.IsZero() {
+				continue
+			}
+
+			if end.IsZero() || !end.After(start) {
+				end = start.AddDate(0, 0, 1)
+			}
+
+			for t := start; t.Before(end); t = t.AddDate(0, 0, 1) {
+				if yearly {
+					c.yearly[t.Format("01-02")] = name
+				} else {
+					c.add(t, name)
+				}
+			}
+		}
+	}
+	return scanner.Err()
+}
+
+// `20241225` or `20241225T000000Z`, only the date part is used
+func parseICSDate(v string) (time.Time, error) {
+	if len(v) > 8 {
+		v = v[:8]
+	}
+	return time.ParseInLocation("20060102", v, time.Local)
+}
+
+// `holidays`, `holidays <year>`
+func holidaysItems(match []string) Items {
+	year := timeNow().Year()
+	if match[1] != "" {
+		year = int(Atoi(match[1]))
+	}
+
+	c := holidays()
+	if c.err != nil {
+		return getItems(datetime{}, c.err)
+	}
+
+	var days []time.Time
+	start := time.Date(year, time.January, 1, 0, 0, 0, 0, time.Local)
+	for t := start; t.Year() == year; t = t.AddDate(0, 0, 1) {
+		if _, ok := c.holiday(t); ok {
+			days = append(days, t)
+		}
+	}
+
+	items := Items{
+		Skipknowldedge: true,
+	}
+
+	for _, t := range days {
+		name, _ := c.holiday(t)
+		items.Items = append(items.Items, Item{
+			Title:    name,
+			Subtitle: formatDate(t),
+			Arg:      t.Format("2006-01-02"),
+		})
+	}
+
+	if len(items.Items) == 0 {
+		items.Items = append(items.Items, Item{
+			Title:    tr("No holidays"),
+			Subtitle: fmt.Sprintf(tr("Set HOLIDAYS in workflow configuration or add .ics/.csv files to %s in workflow data"), holidaysDir),
+			Arg:      "",
+			Valid:    notValid(),
+		})
+	}
+	return items
+}
@@ -0,0 +1,847 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// Output language, LANGUAGE variable
+func language() string {
+	lang := strings.ToLower(getConfig("LANGUAGE", "en"))
+	if _, ok := languages[lang]; !ok {
+		return "en"
+	}
+	return lang
+}
+
+type languageDefinition struct {
+	// Item titles and other phrases, English when missing
+	messages map[string]string
+
+	// Unit names in all plural forms, see plural
+	units map[string][]string
+
+	// Index of plural form for the value,
+	// fraction is set for values shown with decimals
+	plural func(n float64, fraction bool) int
+
+	and      string
+	weekdays [7]string  // from Sunday
+	months   [12]string // as used in a date, e.g. genitive in Polish
+	date     func(t time.Time, weekday string, month string) string
+}
+
+var languages = map[string]languageDefinition{
+	"en": {
+		units: map[string][]string{
+			"year":        {"year", "years"},
+			"month":       {"month", "months"},
+			"week":        {"week", "weeks"},
+			"day":         {"day", "days"},
+			"hour":        {"hour", "hours"},
+			"minute":      {"minute", "minutes"},
+			"second":      {"second", "seconds"},
+			"businessDay": {"business day", "business days"},
+		},
+		plural: func(n float64, fraction bool) int {
+			if n == 1 && !fraction {
+				return 0
+			}
+			return 1
+		},
+		and:      "and",
+		weekdays: [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+		months:   [12]string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+		date: func(t time.Time, weekday string, month string) string {
+			return fmt.Sprintf("%s, %d %s %d", weekday, t.Day(), month, t.Year())
+		},
+	},
+	"pl": {
+		messages: map[string]string{
+			"Result":                "Wynik",
+			"Result (hh:mm:ss)":     "Wynik (hh:mm:ss)",
+			"Result (hh:mm)":        "Wynik (hh:mm)",
+			"In weeks":              "W tygodniach",
+			"In days":               "W dniach",
+			"In hours":              "W godzinach",
+			"In minutes":            "W minutach",
+			"In seconds":            "W sekundach",
+			"Unix timestamp":        "Znacznik czasu Unix",
+			"Date":                  "Data",
+			"Time":                  "Godzina",
+			"Full date":             "Pełna data",
+			"Amount":                "Kwota",
+			"Per day":               "Dziennie",
+			"Per hour":              "Za godzinę",
+			"Input error!":          "Błąd danych!",
+			"Age":                   "Wiek",
+			"Since":                 "Od",
+			"Until":                 "Do",
+			"Total days":            "Łącznie dni",
+			"Total weeks":           "Łącznie tygodni",
+			"Next anniversary":      "Następna rocznica",
+			"Day elapsed":           "Upłynęło z dnia",
+			"Week elapsed":          "Upłynęło z tygodnia",
+			"Calendar days":         "Dni kalendarzowe",
+			"Holiday":               "Święto",
+			"24-hour clock":         "Zegar 24-godzinny",
+			"12-hour clock":         "Zegar 12-godzinny",
+			"Since midnight":        "Od północy",
+			"Date and time":         "Data i godzina",
+			"%d digits, read as %s": "%d cyfr, odczytano jako %s",
+			"milliseconds":          "milisekundy",
+			"microseconds":          "mikrosekundy",
+			"nanoseconds":           "nanosekundy",
+			"Expires":               "Wygasa",
+			"Issued at":             "Wydany",
+			"Not before":            "Ważny od",
+			"Lifetime":              "Czas życia",
+			"expires in %s":         "wygasa za %s",
+			"expired %s ago":        "wygasł %s temu",
+			"issued %s ago":         "wydany %s temu",
+			"issued in %s":          "wydany za %s",
+			"valid since %s ago":    "ważny od %s temu",
+			"valid in %s":           "ważny za %s",
+			"never expires":         "nie wygasa",
+			"Total frames":          "Liczba klatek",
+			"Frame rate":            "Liczba klatek na sekundę",
+			"drop-frame":            "drop-frame",
+			"in %s":                 "za %s",
+			"%s earlier":            "%s wcześniej",
+			"Interval between runs": "Odstęp między uruchomieniami",
+			"Occurrences":           "Wystąpienia",
+			"No occurrences":        "Brak wystąpień",
+			"Copy all dates":        "Kopiuj wszystkie daty",
+			"Week":                  "Tydzień",
+			"Sprint":                "Sprint",
+			"Start":                 "Początek",
+			"End":                   "Koniec",
+			"ISO week":              "Tydzień ISO",
+			"Days until start":      "Dni do początku",
+			"Days since end":        "Dni od końca",
+			"Days remaining":        "Pozostało dni",
+			"Go duration":           "Czas trwania w Go",
+			"Excel serial":          "Liczba seryjna Excela",
+			"Unix timestamp (ms)":   "Znacznik czasu Unix (ms)",
+			"Unix timestamp (µs)":   "Znacznik czasu Unix (µs)",
+			"Unix timestamp (ns)":   "Znacznik czasu Unix (ns)",
+			"%s, in %s (%s)":        "%s, za %s (%s)",
+			"Today! (%s)":           "Dzisiaj! (%s)",
+			"Save as %s":            "Zapisz jako %s",
+			"Clear history":         "Wyczyść historię",
+			"Press Enter to remove all calculations from history": "Naciśnij Enter, aby usunąć wszystkie obliczenia z historii",
+			"History cleared": "Historia wyczyszczona",
+			"No variables":    "Brak zmiennych",
+			"Define one with <name> = <expr>, e.g. standup = 15m": "Zdefiniuj przez <nazwa> = <wyrażenie>, np. standup = 15m",
+			"Delete variable %s":      "Usuń zmienną %s",
+			"Press Enter to delete":   "Naciśnij Enter, aby usunąć",
+			"Variable %s saved":       "Zapisano zmienną %s",
+			"Variable %s deleted":     "Usunięto zmienną %s",
+			"Start timer %s":          "Uruchom stoper %s",
+			"Record lap of %s":        "Zapisz okrążenie %s",
+			"Stop timer %s":           "Zatrzymaj stoper %s",
+			"Delete timer %s":         "Usuń stoper %s",
+			"Timer %s started":        "Uruchomiono stoper %s",
+			"Lap of %s recorded":      "Zapisano okrążenie %s",
+			"Timer %s stopped":        "Zatrzymano stoper %s",
+			"Timer %s deleted":        "Usunięto stoper %s",
+			"Press Enter to confirm":  "Naciśnij Enter, aby potwierdzić",
+			"running":                 "działa",
+			"stopped":                 "zatrzymany",
+			"Timer %s %s, started %s": "Stoper %s %s, uruchomiony %s",
+			"Lap %d: %s":              "Okrążenie %d: %s",
+			"Total %s":                "Razem %s",
+			"No timers":               "Brak stoperów",
+			"Start one with start <name>, e.g. start build": "Uruchom przez start <nazwa>, np. start build",
+			"No holidays": "Brak świąt",
+			"Set HOLIDAYS in workflow configuration or add .ics/.csv files to %s in workflow data": "Ustaw HOLIDAYS w konfiguracji workflow lub dodaj pliki .ics/.csv do %s w danych workflow",
+			"Support my work and Buy me a Coffee!":                                                 "Wesprzyj moją pracę i postaw mi kawę!",
+			"unknown action %s":                                                                    "nieznana akcja %s",
+			"%d has weeks 1 to %d":                                                                 "%d ma tygodnie od 1 do %d",
+			"%s is a reserved name":                                                                "%s jest nazwą zastrzeżoną",
+			"%s needs a date":                                                                      "%s wymaga daty",
+			"%s needs two dates":                                                                   "%s wymaga dwóch dat",
+			"INTERVAL must be positive":                                                            "INTERVAL musi być dodatni",
+			"SPRINT_LENGTH must be a number of days":                                               "SPRINT_LENGTH musi być liczbą dni",
+			"SPRINT_START is not a date: %s":                                                       "SPRINT_START nie jest datą: %s",
+			"alfred_workflow_data not set":                                                         "nie ustawiono alfred_workflow_data",
+			"allowed format: <field> <op> <field> where op = +-*/":                                 "dozwolony format: <pole> <op> <pole>, gdzie op = +-*/",
+			"allowed format: <name> = <expr>":                                                      "dozwolony format: <nazwa> = <wyrażenie>",
+			"allowed format: round [up|down] <duration> to <duration>":                             "dozwolony format: round [up|down] <czas> to <czas>",
+			"amounts and rates go with durations, numbers and their own kind only":                 "kwoty i stawki łączą się tylko z czasem trwania, liczbami i własnym rodzajem",
+			"business days go with dates, other business days and numbers only":                    "dni robocze łączą się tylko z datami, innymi dniami roboczymi i liczbami",
+			"cannot calculate %s %s %s":                                                            "nie można obliczyć %s %s %s",
+			"cannot parse %s":                                                                      "nie można odczytać %s",
+			"cron %s needs a timestamp":                                                            "cron %s wymaga znacznika czasu",
+			"cron expression never runs":                                                           "wyrażenie cron nigdy się nie wykona",
+			"cron needs 5 fields: minute hour day month weekday":                                   "cron wymaga 5 pól: minuta godzina dzień miesiąc dzień tygodnia",
+			"date is before sprint 1":                                                              "data jest przed sprintem 1",
+			"date is in the future, try until":                                                     "data jest w przyszłości, użyj until",
+			"division by zero":                                                                     "dzielenie przez zero",
+			"every needs a period, e.g. 2w or 5bd":                                                 "every wymaga okresu, np. 2w lub 5bd",
+			"every needs a positive period":                                                        "every wymaga dodatniego okresu",
+			"every needs a start date":                                                             "every wymaga daty początkowej",
+			"every needs an end date":                                                              "every wymaga daty końcowej",
+			"holidays file %s: %v":                                                                 "plik świąt %s: %v",
+			"input formatted incorrectly":                                                          "niepoprawny format danych",
+			"invalid BYDAY %s":                                                                     "niepoprawne BYDAY %s",
+			"invalid UNTIL %s":                                                                     "niepoprawne UNTIL %s",
+			"invalid WKST %s":                                                                      "niepoprawne WKST %s",
+			"invalid cron range %s":                                                                "niepoprawny zakres cron %s",
+			"invalid cron step %s":                                                                 "niepoprawny krok cron %s",
+			"invalid cron value %s":                                                                "niepoprawna wartość cron %s",
+			"invalid rule part %s":                                                                 "niepoprawna część reguły %s",
+			"invalid value %s":                                                                     "niepoprawna wartość %s",
+			"line %d: %q is not a date":                                                            "wiersz %d: %q nie jest datą",
+			"missing operator":                                                                     "brak operatora",
+			"needs a date, e.g. 22/11/2024":                                                        "wymaga daty, np. 22/11/2024",
+			"nothing to calculate":                                                                 "nic do obliczenia",
+			"only a timestamp can be shown in %s":                                                  "tylko znacznik czasu można pokazać w %s",
+			"rates per different units, e.g. 120/h + 2/m":                                          "stawki w różnych jednostkach, np. 120/h + 2/m",
+			"result can't be shown as %s":                                                          "wyniku nie można pokazać jako %s",
+			"rrule needs timestamps":                                                               "rrule wymaga znaczników czasu",
+			"rule needs FREQ":                                                                      "reguła wymaga FREQ",
+			"set SPRINT_START to the first day of sprint 1":                                        "ustaw SPRINT_START na pierwszy dzień sprintu 1",
+			"sprints are counted from 1":                                                           "sprinty liczone są od 1",
+			"timer %s already stopped":                                                             "stoper %s jest już zatrzymany",
+			"unknown time zone %s":                                                                 "nieznana strefa czasowa %s",
+			"unknown timer %s":                                                                     "nieznany stoper %s",
+			"unknown variable %s":                                                                  "nieznana zmienna %s",
+			"unsupported FREQ %s":                                                                  "nieobsługiwane FREQ %s",
+			"unsupported rule part %s":                                                             "nieobsługiwana część reguły %s",
+			"until needs a date or time":                                                           "until wymaga daty lub godziny",
+			"work hours needs two timestamps":                                                      "work hours wymaga dwóch znaczników czasu",
+			"working time goes with dates, durations and numbers only":                             "czas pracy łączy się tylko z datami, czasem trwania i liczbami",
+			"a time of day goes with durations and other times of day only":                        "godzina łączy się tylko z czasem trwania i innymi godzinami",
+			"timecodes at different frame rates, e.g. @25fps and @30fps":                           "timecody o różnej liczbie klatek, np. @25fps i @30fps",
+			"timecodes go with durations, numbers and other timecodes only":                        "timecody łączą się tylko z czasem trwania, liczbami i innymi timecodami",
+			"COUNT must be positive":                                                               "COUNT musi być dodatni",
+			"every needs a count of at least 1":                                                    "every wymaga liczby co najmniej 1",
+			"Showing first %d dates":                                                               "Pokazano pierwsze %d dat",
+			"Use a later start or an earlier end for the rest":                                     "Dla pozostałych użyj późniejszego początku lub wcześniejszego końca",
+		},
+		units: map[string][]string{
+			"year":        {"rok", "lata", "lat", "roku"},
+			"month":       {"miesiąc", "miesiące", "miesięcy", "miesiąca"},
+			"week":        {"tydzień", "tygodnie", "tygodni", "tygodnia"},
+			"day":         {"dzień", "dni", "dni", "dnia"},
+			"hour":        {"godzina", "godziny", "godzin", "godziny"},
+			"minute":      {"minuta", "minuty", "minut", "minuty"},
+			"second":      {"sekunda", "sekundy", "sekund", "sekundy"},
+			"businessDay": {"dzień roboczy", "dni robocze", "dni roboczych", "dnia roboczego"},
+		},
+		// 1 dzień, 2-4 dni (but 12-14 dni), 5 dni, 1,5 dnia
+		plural: func(n float64, fraction bool) int {
+			if fraction {
+				return 3
+			}
+			i := int64(n)
+			if i == 1 {
+				return 0
+			} else if i%10 >= 2 && i%10 <= 4 && (i%100 < 12 || i%100 > 14) {
+				return 1
+			}
+			return 2
+		},
+		and:      "i",
+		weekdays: [7]string{"niedziela", "poniedziałek", "wtorek", "środa", "czwartek", "piątek", "sobota"},
+		months:   [12]string{"stycznia", "lutego", "marca", "kwietnia", "maja", "czerwca", "lipca", "sierpnia", "września", "października", "listopada", "grudnia"},
+		date: func(t time.Time, weekday string, month string) string {
+			return fmt.Sprintf("%s, %d %s %d", weekday, t.Day(), month, t.Year())
+		},
+	},
+	"de": {
+		messages: map[string]string{
+			"Result":                "Ergebnis",
+			"Result (hh:mm:ss)":     "Ergebnis (hh:mm:ss)",
+			"Result (hh:mm)":        "Ergebnis (hh:mm)",
+			"In weeks":              "In Wochen",
+			"In days":               "In Tagen",
+			"In hours":              "In Stunden",
+			"In minutes":            "In Minuten",
+			"In seconds":            "In Sekunden",
+			"Unix timestamp":        "Unix-Zeitstempel",
+			"Date":                  "Datum",
+			"Time":                  "Uhrzeit",
+			"Full date":             "Vollständiges Datum",
+			"Amount":                "Betrag",
+			"Per day":               "Pro Tag",
+			"Per hour":              "Pro Stunde",
+			"Input error!":          "Eingabefehler!",
+			"Age":                   "Alter",
+			"Since":                 "Seit",
+			"Until":                 "Bis",
+			"Total days":            "Tage insgesamt",
+			"Total weeks":           "Wochen insgesamt",
+			"Next anniversary":      "Nächster Jahrestag",
+			"Day elapsed":           "Vom Tag vergangen",
+			"Week elapsed":          "Von der Woche vergangen",
+			"Calendar days":         "Kalendertage",
+			"Holiday":               "Feiertag",
+			"24-hour clock":         "24-Stunden-Uhr",
+			"12-hour clock":         "12-Stunden-Uhr",
+			"Since midnight":        "Seit Mitternacht",
+			"Date and time":         "Datum und Uhrzeit",
+			"%d digits, read as %s": "%d Ziffern, gelesen als %s",
+			"milliseconds":          "Millisekunden",
+			"microseconds":          "Mikrosekunden",
+			"nanoseconds":           "Nanosekunden",
+			"Expires":               "Läuft ab",
+			"Issued at":             "Ausgestellt",
+			"Not before":            "Nicht vor",
+			"Lifetime":              "Lebensdauer",
+			"expires in %s":         "läuft in %s ab",
+			"expired %s ago":        "vor %s abgelaufen",
+			"issued %s ago":         "vor %s ausgestellt",
+			"issued in %s":          "ausgestellt in %s",
+			"valid since %s ago":    "gültig seit %s",
+			"valid in %s":           "gültig in %s",
+			"never expires":         "läuft nie ab",
+			"Total frames":          "Bilder insgesamt",
+			"Frame rate":            "Bildrate",
+			"drop-frame":            "Drop-Frame",
+			"in %s":                 "in %s",
+			"%s earlier":            "%s früher",
+			"Interval between runs": "Abstand zwischen Ausführungen",
+			"Occurrences":           "Vorkommen",
+			"No occurrences":        "Keine Vorkommen",
+			"Copy all dates":        "Alle Daten kopieren",
+			"Week":                  "Woche",
+			"Sprint":                "Sprint",
+			"Start":                 "Beginn",
+			"End":                   "Ende",
+			"ISO week":              "ISO-Woche",
+			"Days until start":      "Tage bis Beginn",
+			"Days since end":        "Tage seit Ende",
+			"Days remaining":        "Verbleibende Tage",
+			"Go duration":           "Go-Dauer",
+			"Excel serial":          "Excel-Seriennummer",
+			"Unix timestamp (ms)":   "Unix-Zeitstempel (ms)",
+			"Unix timestamp (µs)":   "Unix-Zeitstempel (µs)",
+			"Unix timestamp (ns)":   "Unix-Zeitstempel (ns)",
+			"%s, in %s (%s)":        "%s, in %s (%s)",
+			"Today! (%s)":           "Heute! (%s)",
+			"Save as %s":            "Speichern als %s",
+			"Clear history":         "Verlauf löschen",
+			"Press Enter to remove all calculations from history": "Enter drücken, um alle Berechnungen aus dem Verlauf zu entfernen",
+			"History cleared": "Verlauf gelöscht",
+			"No variables":    "Keine Variablen",
+			"Define one with <name> = <expr>, e.g. standup = 15m": "Mit <Name> = <Ausdruck> definieren, z. B. standup = 15m",
+			"Delete variable %s":      "Variable %s löschen",
+			"Press Enter to delete":   "Enter drücken zum Löschen",
+			"Variable %s saved":       "Variable %s gespeichert",
+			"Variable %s deleted":     "Variable %s gelöscht",
+			"Start timer %s":          "Timer %s starten",
+			"Record lap of %s":        "Runde von %s erfassen",
+			"Stop timer %s":           "Timer %s stoppen",
+			"Delete timer %s":         "Timer %s löschen",
+			"Timer %s started":        "Timer %s gestartet",
+			"Lap of %s recorded":      "Runde von %s erfasst",
+			"Timer %s stopped":        "Timer %s gestoppt",
+			"Timer %s deleted":        "Timer %s gelöscht",
+			"Press Enter to confirm":  "Enter drücken zum Bestätigen",
+			"running":                 "läuft",
+			"stopped":                 "gestoppt",
+			"Timer %s %s, started %s": "Timer %s %s, gestartet %s",
+			"Lap %d: %s":              "Runde %d: %s",
+			"Total %s":                "Gesamt %s",
+			"No timers":               "Keine Timer",
+			"Start one with start <name>, e.g. start build": "Mit start <Name> starten, z. B. start build",
+			"No holidays": "Keine Feiertage",
+			"Set HOLIDAYS in workflow configuration or add .ics/.csv files to %s in workflow data": "HOLIDAYS in der Workflow-Konfiguration setzen oder .ics/.csv-Dateien zu %s in den Workflow-Daten hinzufügen",
+			"Support my work and Buy me a Coffee!":                                                 "Unterstütze meine Arbeit und spendiere mir einen Kaffee!",
+			"unknown action %s":                                                                    "unbekannte Aktion %s",
+			"%d has weeks 1 to %d":                                                                 "%d hat die Wochen 1 bis %d",
+			"%s is a reserved name":                                                                "%s ist ein reservierter Name",
+			"%s needs a date":                                                                      "%s braucht ein Datum",
+			"%s needs two dates":                                                                   "%s braucht zwei Daten",
+			"INTERVAL must be positive":                                                            "INTERVAL muss positiv sein",
+			"SPRINT_LENGTH must be a number of days":                                               "SPRINT_LENGTH muss eine Anzahl von Tagen sein",
+			"SPRINT_START is not a date: %s":                                                       "SPRINT_START ist kein Datum: %s",
+			"alfred_workflow_data not set":                                                         "alfred_workflow_data nicht gesetzt",
+			"allowed format: <field> <op> <field> where op = +-*/":                                 "erlaubtes Format: <Feld> <Op> <Feld>, wobei Op = +-*/",
+			"allowed format: <name> = <expr>":                                                      "erlaubtes Format: <Name> = <Ausdruck>",
+			"allowed format: round [up|down] <duration> to <duration>":                             "erlaubtes Format: round [up|down] <Dauer> to <Dauer>",
+			"amounts and rates go with durations, numbers and their own kind only":                 "Beträge und Sätze passen nur zu Dauern, Zahlen und ihrer eigenen Art",
+			"business days go with dates, other business days and numbers only":                    "Werktage passen nur zu Daten, anderen Werktagen und Zahlen",
+			"cannot calculate %s %s %s":                                                            "%s %s %s kann nicht berechnet werden",
+			"cannot parse %s":                                                                      "%s kann nicht gelesen werden",
+			"cron %s needs a timestamp":                                                            "cron %s braucht einen Zeitpunkt",
+			"cron expression never runs":                                                           "Cron-Ausdruck läuft nie",
+			"cron needs 5 fields: minute hour day month weekday":                                   "cron braucht 5 Felder: Minute Stunde Tag Monat Wochentag",
+			"date is before sprint 1":                                                              "Datum liegt vor Sprint 1",
+			"date is in the future, try until":                                                     "Datum liegt in der Zukunft, until verwenden",
+			"division by zero":                                                                     "Division durch null",
+			"every needs a period, e.g. 2w or 5bd":                                                 "every braucht einen Zeitraum, z. B. 2w oder 5bd",
+			"every needs a positive period":                                                        "every braucht einen positiven Zeitraum",
+			"every needs a start date":                                                             "every braucht ein Startdatum",
+			"every needs an end date":                                                              "every braucht ein Enddatum",
+			"holidays file %s: %v":                                                                 "Feiertagsdatei %s: %v",
+			"input formatted incorrectly":                                                          "Eingabe falsch formatiert",
+			"invalid BYDAY %s":                                                                     "ungültiges BYDAY %s",
+			"invalid UNTIL %s":                                                                     "ungültiges UNTIL %s",
+			"invalid WKST %s":                                                                      "ungültiges WKST %s",
+			"invalid cron range %s":                                                                "ungültiger Cron-Bereich %s",
+			"invalid cron step %s":                                                                 "ungültiger Cron-Schritt %s",
+			"invalid cron value %s":                                                                "ungültiger Cron-Wert %s",
+			"invalid rule part %s":                                                                 "ungültiger Regelteil %s",
+			"invalid value %s":                                                                     "ungültiger Wert %s",
+			"line %d: %q is not a date":                                                            "Zeile %d: %q ist kein Datum",
+			"missing operator":                                                                     "Operator fehlt",
+			"needs a date, e.g. 22/11/2024":                                                        "braucht ein Datum, z. B. 22/11/2024",
+			"nothing to calculate":                                                                 "nichts zu berechnen",
+			"only a timestamp can be shown in %s":                                                  "nur ein Zeitpunkt kann in %s angezeigt werden",
+			"rates per different units, e.g. 120/h + 2/m":                                          "Sätze pro unterschiedlicher Einheit, z. B. 120/h + 2/m",
+			"result can't be shown as %s":                                                          "Ergebnis kann nicht als %s angezeigt werden",
+			"rrule needs timestamps":                                                               "rrule braucht Zeitpunkte",
+			"rule needs FREQ":                                                                      "Regel braucht FREQ",
+			"set SPRINT_START to the first day of sprint 1":                                        "SPRINT_START auf den ersten Tag von Sprint 1 setzen",
+			"sprints are counted from 1":                                                           "Sprints werden ab 1 gezählt",
+			"timer %s already stopped":                                                             "Timer %s ist bereits gestoppt",
+			"unknown time zone %s":                                                                 "unbekannte Zeitzone %s",
+			"unknown timer %s":                                                                     "unbekannter Timer %s",
+			"unknown variable %s":                                                                  "unbekannte Variable %s",
+			"unsupported FREQ %s":                                                                  "nicht unterstütztes FREQ %s",
+			"unsupported rule part %s":                                                             "nicht unterstützter Regelteil %s",
+			"until needs a date or time":                                                           "until braucht ein Datum oder eine Uhrzeit",
+			"work hours needs two timestamps":                                                      "work hours braucht zwei Zeitpunkte",
+			"working time goes with dates, durations and numbers only":                             "Arbeitszeit passt nur zu Daten, Dauern und Zahlen",
+			"a time of day goes with durations and other times of day only":                        "eine Uhrzeit passt nur zu Dauern und anderen Uhrzeiten",
+			"timecodes at different frame rates, e.g. @25fps and @30fps":                           "Timecodes mit unterschiedlicher Bildrate, z. B. @25fps und @30fps",
+			"timecodes go with durations, numbers and other timecodes only":                        "Timecodes passen nur zu Dauern, Zahlen und anderen Timecodes",
+			"COUNT must be positive":                                                               "COUNT muss positiv sein",
+			"every needs a count of at least 1":                                                    "every braucht eine Anzahl von mindestens 1",
+			"Showing first %d dates":                                                               "Die ersten %d Daten werden angezeigt",
+			"Use a later start or an earlier end for the rest":                                     "Für den Rest einen späteren Beginn oder ein früheres Ende wählen",
+		},
+		units: map[string][]string{
+			"year":        {"Jahr", "Jahre"},
+			"month":       {"Monat", "Monate"},
+			"week":        {"Woche", "Wochen"},
+			"day":         {"Tag", "Tage"},
+			"hour":        {"Stunde", "Stunden"},
+			"minute":      {"Minute", "Minuten"},
+			"second":      {"Sekunde", "Sekunden"},
+			"businessDay": {"Arbeitstag", "Arbeitstage"},
+		},
+		plural: func(n float64, fraction bool) int {
+			if n == 1 && !fraction {
+				return 0
+			}
+			return 1
+		},
+		and:      "und",
+		weekdays: [7]string{"Sonntag", "Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag"},
+		months:   [12]string{"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli", "August", "September", "Oktober", "November", "Dezember"},
+		date: func(t time.Time, weekday string, month string) string {
+			return fmt.Sprintf("%s, %d. %s %d", weekday, t.Day(), month, t.Year())
+		},
+	},
+	"fr": {
+		messages: map[string]string{
+			"Result":                "Résultat",
+			"Result (hh:mm:ss)":     "Résultat (hh:mm:ss)",
+			"Result (hh:mm)":        "Résultat (hh:mm)",
+			"In weeks":              "En semaines",
+			"In days":               "En jours",
+			"In hours":              "En heures",
+			"In minutes":            "En minutes",
+			"In seconds":            "En secondes",
+			"Unix timestamp":        "Horodatage Unix",
+			"Date":                  "Date",
+			"Time":                  "Heure",
+			"Full date":             "Date complète",
+			"Amount":                "Montant",
+			"Per day":               "Par jour",
+			"Per hour":              "Par heure",
+			"Input error!":          "Erreur de saisie !",
+			"Age":                   "Âge",
+			"Since":                 "Depuis",
+			"Until":                 "Jusqu'à",
+			"Total days":            "Total en jours",
+			"Total weeks":           "Total en semaines",
+			"Next anniversary":      "Prochain anniversaire",
+			"Day elapsed":           "Journée écoulée",
+			"Week elapsed":          "Semaine écoulée",
+			"Calendar days":         "Jours calendaires",
+			"Holiday":               "Jour férié",
+			"24-hour clock":         "Horloge 24 heures",
+			"12-hour clock":         "Horloge 12 heures",
+			"Since midnight":        "Depuis minuit",
+			"Date and time":         "Date et heure",
+			"%d digits, read as %s": "%d chiffres, lu en %s",
+			"milliseconds":          "millisecondes",
+			"microseconds":          "microsecondes",
+			"nanoseconds":           "nanosecondes",
+			"Expires":               "Expire",
+			"Issued at":             "Émis le",
+			"Not before":            "Pas avant",
+			"Lifetime":              "Durée de vie",
+			"expires in %s":         "expire dans %s",
+			"expired %s ago":        "expiré il y a %s",
+			"issued %s ago":         "émis il y a %s",
+			"issued in %s":          "émis dans %s",
+			"valid since %s ago":    "valide depuis %s",
+			"valid in %s":           "valide dans %s",
+			"never expires":         "n'expire jamais",
+			"Total frames":          "Nombre d'images",
+			"Frame rate":            "Fréquence d'images",
+			"drop-frame":            "drop-frame",
+			"in %s":                 "dans %s",
+			"%s earlier":            "%s plus tôt",
+			"Interval between runs": "Intervalle entre exécutions",
+			"Occurrences":           "Occurrences",
+			"No occurrences":        "Aucune occurrence",
+			"Copy all dates":        "Copier toutes les dates",
+			"Week":                  "Semaine",
+			"Sprint":                "Sprint",
+			"Start":                 "Début",
+			"End":                   "Fin",
+			"ISO week":              "Semaine ISO",
+			"Days until start":      "Jours avant le début",
+			"Days since end":        "Jours depuis la fin",
+			"Days remaining":        "Jours restants",
+			"Go duration":           "Durée Go",
+			"Excel serial":          "Numéro de série Excel",
+			"Unix timestamp (ms)":   "Horodatage Unix (ms)",
+			"Unix timestamp (µs)":   "Horodatage Unix (µs)",
+			"Unix timestamp (ns)":   "Horodatage Unix (ns)",
+			"%s, in %s (%s)":        "%s, dans %s (%s)",
+			"Today! (%s)":           "Aujourd'hui ! (%s)",
+			"Save as %s":            "Enregistrer sous %s",
+			"Clear history":         "Effacer l'historique",
+			"Press Enter to remove all calculations from history": "Appuyez sur Entrée pour supprimer tous les calculs de l'historique",
+			"History cleared": "Historique effacé",
+			"No variables":    "Aucune variable",
+			"Define one with <name> = <expr>, e.g. standup = 15m": "Définissez-en une avec <nom> = <expr>, p. ex. standup = 15m",
+			"Delete variable %s":      "Supprimer la variable %s",
+			"Press Enter to delete":   "Appuyez sur Entrée pour supprimer",
+			"Variable %s saved":       "Variable %s enregistrée",
+			"Variable %s deleted":     "Variable %s supprimée",
+			"Start timer %s":          "Démarrer le minuteur %s",
+			"Record lap of %s":        "Enregistrer un tour de %s",
+			"Stop timer %s":           "Arrêter le minuteur %s",
+			"Delete timer %s":         "Supprimer le minuteur %s",
+			"Timer %s started":        "Minuteur %s démarré",
+			"Lap of %s recorded":      "Tour de %s enregistré",
+			"Timer %s stopped":        "Minuteur %s arrêté",
+			"Timer %s deleted":        "Minuteur %s supprimé",
+			"Press Enter to confirm":  "Appuyez sur Entrée pour confirmer",
+			"running":                 "en cours",
+			"stopped":                 "arrêté",
+			"Timer %s %s, started %s": "Minuteur %s %s, démarré %s",
+			"Lap %d: %s":              "Tour %d : %s",
+			"Total %s":                "Total %s",
+			"No timers":               "Aucun minuteur",
+			"Start one with start <name>, e.g. start build": "Démarrez-en un avec start <nom>, p. ex. start build",
+			"No holidays": "Aucun jour férié",
+			"Set HOLIDAYS in workflow configuration or add .ics/.csv files to %s in workflow data": "Définissez HOLIDAYS dans la configuration du workflow ou ajoutez des fichiers .ics/.csv à %s dans les données du workflow",
+			"Support my work and Buy me a Coffee!":                                                 "Soutenez mon travail et offrez-moi un café !",
+			"unknown action %s":                                                                    "action inconnue %s",
+			"%d has weeks 1 to %d":                                                                 "%d a les semaines 1 à %d",
+			"%s is a reserved name":                                                                "%s est un nom réservé",
+			"%s needs a date":                                                                      "%s nécessite une date",
+			"%s needs two dates":                                                                   "%s nécessite deux dates",
+			"INTERVAL must be positive":                                                            "INTERVAL doit être positif",
+			"SPRINT_LENGTH must be a number of days":                                               "SPRINT_LENGTH doit être un nombre de jours",
+			"SPRINT_START is not a date: %s":                                                       "SPRINT_START n'est pas une date : %s",
+			"alfred_workflow_data not set":                                                         "alfred_workflow_data non défini",
+			"allowed format: <field> <op> <field> where op = +-*/":                                 "format autorisé : <champ> <op> <champ> où op = +-*/",
+			"allowed format: <name> = <expr>":                                                      "format autorisé : <nom> = <expr>",
+			"allowed format: round [up|down] <duration> to <duration>":                             "format autorisé : round [up|down] <durée> to <durée>",
+			"amounts and rates go with durations, numbers and their own kind only":                 "les montants et tarifs ne vont qu'avec des durées, des nombres et leur propre type",
+			"business days go with dates, other business days and numbers only":                    "les jours ouvrés ne vont qu'avec des dates, d'autres jours ouvrés et des nombres",
+			"cannot calculate %s %s %s":                                                            "impossible de calculer %s %s %s",
+			"cannot parse %s":                                                                      "impossible de lire %s",
+			"cron %s needs a timestamp":                                                            "cron %s nécessite un horodatage",
+			"cron expression never runs":                                                           "l'expression cron ne s'exécute jamais",
+			"cron needs 5 fields: minute hour day month weekday":                                   "cron nécessite 5 champs : minute heure jour mois jour de la semaine",
+			"date is before sprint 1":                                                              "la date est avant le sprint 1",
+			"date is in the future, try until":                                                     "la date est dans le futur, essayez until",
+			"division by zero":                                                                     "division par zéro",
+			"every needs a period, e.g. 2w or 5bd":                                                 "every nécessite une période, p. ex. 2w ou 5bd",
+			"every needs a positive period":                                                        "every nécessite une période positive",
+			"every needs a start date":                                                             "every nécessite une date de début",
+			"every needs an end date":                                                              "every nécessite une date de fin",
+			"holidays file %s: %v":                                                                 "fichier de jours fériés %s : %v",
+			"input formatted incorrectly":                                                          "saisie mal formatée",
+			"invalid BYDAY %s":                                                                     "BYDAY invalide %s",
+			"invalid UNTIL %s":                                                                     "UNTIL invalide %s",
+			"invalid WKST %s":                                                                      "WKST invalide %s",
+			"invalid cron range %s":                                                                "plage cron invalide %s",
+			"invalid cron step %s":                                                                 "pas cron invalide %s",
+			"invalid cron value %s":                                                                "valeur cron invalide %s",
+			"invalid rule part %s":                                                                 "partie de règle invalide %s",
+			"invalid value %s":                                                                     "valeur invalide %s",
+			"line %d: %q is not a date":                                                            "ligne %d : %q n'est pas une date",
+			"missing operator":                                                                     "opérateur manquant",
+			"needs a date, e.g. 22/11/2024":                                                        "nécessite une date, p. ex. 22/11/2024",
+			"nothing to calculate":                                                                 "rien à calculer",
+			"only a timestamp can be shown in %s":                                                  "seul un horodatage peut être affiché en %s",
+			"rates per different units, e.g. 120/h + 2/m":                                          "tarifs par unités différentes, p. ex. 120/h + 2/m",
+			"result can't be shown as %s":                                                          "le résultat ne peut pas être affiché en %s",
+			"rrule needs timestamps":                                                               "rrule nécessite des horodatages",
+			"rule needs FREQ":                                                                      "la règle nécessite FREQ",
+			"set SPRINT_START to the first day of sprint 1":                                        "définissez SPRINT_START au premier jour du sprint 1",
+			"sprints are counted from 1":                                                           "les sprints sont comptés à partir de 1",
+			"timer %s already stopped":                                                             "le minuteur %s est déjà arrêté",
+			"unknown time zone %s":                                                                 "fuseau horaire inconnu %s",
+			"unknown timer %s":                                                                     "minuteur inconnu %s",
+			"unknown variable %s":                                                                  "variable inconnue %s",
+			"unsupported FREQ %s":                                                                  "FREQ non pris en charge %s",
+			"unsupported rule part %s":                                                             "partie de règle non prise en charge %s",
+			"until needs a date or time":                                                           "until nécessite une date ou une heure",
+			"work hours needs two timestamps":                                                      "work hours nécessite deux horodatages",
+			"working time goes with dates, durations and numbers only":                             "le temps de travail ne va qu'avec des dates, des durées et des nombres",
+			"a time of day goes with durations and other times of day only":                        "une heure ne va qu'avec des durées et d'autres heures",
+			"timecodes at different frame rates, e.g. @25fps and @30fps":                           "timecodes à des fréquences d'images différentes, p. ex. @25fps et @30fps",
+			"timecodes go with durations, numbers and other timecodes only":                        "les timecodes ne vont qu'avec des durées, des nombres et d'autres timecodes",
+			"COUNT must be positive":                                                               "COUNT doit être positif",
+			"every needs a count of at least 1":                                                    "every nécessite un nombre d'au moins 1",
+			"Showing first %d dates":                                                               "Affichage des %d premières dates",
+			"Use a later start or an earlier end for the rest":                                     "Pour la suite, choisissez un début plus tardif ou une fin plus tôt",
+		},
+		units: map[string][]string{
+			"year":        {"an", "ans"},
+			"month":       {"mois", "mois"},
+			"week":        {"semaine", "semaines"},
+			"day":         {"jour", "jours"},
+			"hour":        {"heure", "heures"},
+			"minute":      {"minute", "minutes"},
+			"second":      {"seconde", "secondes"},
+			"businessDay": {"jour ouvré", "jours ouvrés"},
+		},
+		// singular below 2, e.g. 0 jour, 1,5 jour
+		plural: func(n float64, fraction bool) int {
+			if math.Abs(n) < 2 {
+				return 0
+			}
+			return 1
+		},
+		and:      "et",
+		weekdays: [7]string{"dimanche", "lundi", "mardi", "mercredi", "jeudi", "vendredi", "samedi"},
+		months:   [12]string{"janvier", "février", "mars", "avril", "mai", "juin", "juillet", "août", "septembre", "octobre", "novembre", "décembre"},
+		date: func(t time.Time, weekday string, month string) string {
+			return fmt.Sprintf("%s %d %s %d", weekday, t.Day(), month, t.Year())
+		},
+	},
+	"es": {
+		messages: map[string]string{
+			"Result":                "Resultado",
+			"Result (hh:mm:ss)":     "Resultado (hh:mm:ss)",
+			"Result (hh:mm)":        "Resultado (hh:mm)",
+			"In weeks":              "En semanas",
+			"In days":               "En días",
+			"In hours":              "En horas",
+			"In minutes":            "En minutos",
+			"In seconds":            "En segundos",
+			"Unix timestamp":        "Marca de tiempo Unix",
+			"Date":                  "Fecha",
+			"Time":                  "Hora",
+			"Full date":             "Fecha completa",
+			"Amount":                "Importe",
+			"Per day":               "Por día",
+			"Per hour":              "Por hora",
+			"Input error!":          "¡Error de entrada!",
+			"Age":                   "Edad",
+			"Since":                 "Desde",
+			"Until":                 "Hasta",
+			"Total days":            "Total de días",
+			"Total weeks":           "Total de semanas",
+			"Next anniversary":      "Próximo aniversario",
+			"Day elapsed":           "Transcurrido del día",
+			"Week elapsed":          "Transcurrido de la semana",
+			"Calendar days":         "Días naturales",
+			"Holiday":               "Festivo",
+			"24-hour clock":         "Reloj de 24 horas",
+			"12-hour clock":         "Reloj de 12 horas",
+			"Since midnight":        "Desde medianoche",
+			"Date and time":         "Fecha y hora",
+			"%d digits, read as %s": "%d dígitos, leído como %s",
+			"milliseconds":          "milisegundos",
+			"microseconds":          "microsegundos",
+			"nanoseconds":           "nanosegundos",
+			"Expires":               "Caduca",
+			"Issued at":             "Emitido",
+			"Not before":            "No antes de",
+			"Lifetime":              "Vigencia",
+			"expires in %s":         "caduca en %s",
+			"expired %s ago":        "caducó hace %s",
+			"issued %s ago":         "emitido hace %s",
+			"issued in %s":          "emitido en %s",
+			"valid since %s ago":    "válido desde hace %s",
+			"valid in %s":           "válido en %s",
+			"never expires":         "no caduca",
+			"Total frames":          "Total de fotogramas",
+			"Frame rate":            "Fotogramas por segundo",
+			"drop-frame":            "drop-frame",
+			"in %s":                 "en %s",
+			"%s earlier":            "%s antes",
+			"Interval between runs": "Intervalo entre ejecuciones",
+			"Occurrences":           "Repeticiones",
+			"No occurrences":        "Sin repeticiones",
+			"Copy all dates":        "Copiar todas las fechas",
+			"Week":                  "Semana",
+			"Sprint":                "Sprint",
+			"Start":                 "Inicio",
+			"End":                   "Fin",
+			"ISO week":              "Semana ISO",
+			"Days until start":      "Días hasta el inicio",
+			"Days since end":        "Días desde el fin",
+			"Days remaining":        "Días restantes",
+			"Go duration":           "Duración de Go",
+			"Excel serial":          "Número de serie de Excel",
+			"Unix timestamp (ms)":   "Marca de tiempo Unix (ms)",
+			"Unix timestamp (µs)":   "Marca de tiempo Unix (µs)",
+			"Unix timestamp (ns)":   "Marca de tiempo Unix (ns)",
+			"%s, in %s (%s)":        "%s, en %s (%s)",
+			"Today! (%s)":           "¡Hoy! (%s)",
+			"Save as %s":            "Guardar como %s",
+			"Clear history":         "Borrar historial",
+			"Press Enter to remove all calculations from history": "Pulsa Intro para borrar todos los cálculos del historial",
+			"History cleared": "Historial borrado",
+			"No variables":    "Sin variables",
+			"Define one with <name> = <expr>, e.g. standup = 15m": "Define una con <nombre> = <expr>, p. ej. standup = 15m",
+			"Delete variable %s":      "Eliminar variable %s",
+			"Press Enter to delete":   "Pulsa Intro para eliminar",
+			"Variable %s saved":       "Variable %s guardada",
+			"Variable %s deleted":     "Variable %s eliminada",
+			"Start timer %s":          "Iniciar temporizador %s",
+			"Record lap of %s":        "Registrar vuelta de %s",
+			"Stop timer %s":           "Detener temporizador %s",
+			"Delete timer %s":         "Eliminar temporizador %s",
+			"Timer %s started":        "Temporizador %s iniciado",
+			"Lap of %s recorded":      "Vuelta de %s registrada",
+			"Timer %s stopped":        "Temporizador %s detenido",
+			"Timer %s deleted":        "Temporizador %s eliminado",
+			"Press Enter to confirm":  "Pulsa Intro para confirmar",
+			"running":                 "en marcha",
+			"stopped":                 "detenido",
+			"Timer %s %s, started %s": "Temporizador %s %s, iniciado %s",
+			"Lap %d: %s":              "Vuelta %d: %s",
+			"Total %s":                "Total %s",
+			"No timers":               "Sin temporizadores",
+			"Start one with start <name>, e.g. start build": "Inicia uno con start <nombre>, p. ej. start build",
+			"No holidays": "Sin festivos",
+			"Set HOLIDAYS in workflow configuration or add .ics/.csv files to %s in workflow data": "Define HOLIDAYS en la configuración del workflow o añade archivos .ics/.csv a %s en los datos del workflow",
+			"Support my work and Buy me a Coffee!":                                                 "¡Apoya mi trabajo e invítame a un café!",
+			"unknown action %s":                                                                    "acción desconocida %s",
+			"%d has weeks 1 to %d":                                                                 "%d tiene las semanas 1 a %d",
+			"%s is a reserved name":                                                                "%s es un nombre reservado",
+			"%s needs a date":                                                                      "%s necesita una fecha",
+			"%s needs two dates":                                                                   "%s necesita dos fechas",
+			"INTERVAL must be positive":                                                            "INTERVAL debe ser positivo",
+			"SPRINT_LENGTH must be a number of days":                                               "SPRINT_LENGTH debe ser un número de días",
+			"SPRINT_START is not a date: %s":                                                       "SPRINT_START no es una fecha: %s",
+			"alfred_workflow_data not set":                                                         "alfred_workflow_data no está definido",
+			"allowed format: <field> <op> <field> where op = +-*/":                                 "formato permitido: <campo> <op> <campo> donde op = +-*/",
+			"allowed format: <name> = <expr>":                                                      "formato permitido: <nombre> = <expr>",
+			"allowed format: round [up|down] <duration> to <duration>":                             "formato permitido: round [up|down] <duración> to <duración>",
+			"amounts and rates go with durations, numbers and their own kind only":                 "los importes y tarifas solo van con duraciones, números y su propio tipo",
+			"business days go with dates, other business days and numbers only":                    "los días laborables solo van con fechas, otros días laborables y números",
+			"cannot calculate %s %s %s":                                                            "no se puede calcular %s %s %s",
+			"cannot parse %s":                                                                      "no se puede leer %s",
+			"cron %s needs a timestamp":                                                            "cron %s necesita una marca de tiempo",
+			"cron expression never runs":                                                           "la expresión cron nunca se ejecuta",
+			"cron needs 5 fields: minute hour day month weekday":                                   "cron necesita 5 campos: minuto hora día mes día de la semana",
+			"date is before sprint 1":                                                              "la fecha es anterior al sprint 1",
+			"date is in the future, try until":                                                     "la fecha está en el futuro, prueba until",
+			"division by zero":                                                                     "división por cero",
+			"every needs a period, e.g. 2w or 5bd":                                                 "every necesita un periodo, p. ej. 2w o 5bd",
+			"every needs a positive period":                                                        "every necesita un periodo positivo",
+			"every needs a start date":                                                             "every necesita una fecha de inicio",
+			"every needs an end date":                                                              "every necesita una fecha de fin",
+			"holidays file %s: %v":                                                                 "archivo de festivos %s: %v",
+			"input formatted incorrectly":                                                          "entrada con formato incorrecto",
+			"invalid BYDAY %s":                                                                     "BYDAY no válido %s",
+			"invalid UNTIL %s":                                                                     "UNTIL no válido %s",
+			"invalid WKST %s":                                                                      "WKST no válido %s",
+			"invalid cron range %s":                                                                "rango cron no válido %s",
+			"invalid cron step %s":                                                                 "paso cron no válido %s",
+			"invalid cron value %s":                                                                "valor cron no válido %s",
+			"invalid rule part %s":                                                                 "parte de regla no válida %s",
+			"invalid value %s":                                                                     "valor no válido %s",
+			"line %d: %q is not a date":                                                            "línea %d: %q no es una fecha",
+			"missing operator":                                                                     "falta un operador",
+			"needs a date, e.g. 22/11/2024":                                                        "necesita una fecha, p. ej. 22/11/2024",
+			"nothing to calculate":                                                                 "nada que calcular",
+			"only a timestamp can be shown in %s":                                                  "solo una marca de tiempo se puede mostrar en %s",
+			"rates per different units, e.g. 120/h + 2/m":                                          "tarifas por unidades distintas, p. ej. 120/h + 2/m",
+			"result can't be shown as %s":                                                          "el resultado no se puede mostrar como %s",
+			"rrule needs timestamps":                                                               "rrule necesita marcas de tiempo",
+			"rule needs FREQ":                                                                      "la regla necesita FREQ",
+			"set SPRINT_START to the first day of sprint 1":                                        "define SPRINT_START como el primer día del sprint 1",
+			"sprints are counted from 1":                                                           "los sprints se cuentan desde 1",
+			"timer %s already stopped":                                                             "el temporizador %s ya está detenido",
+			"unknown time zone %s":                                                                 "zona horaria desconocida %s",
+			"unknown timer %s":                                                                     "temporizador desconocido %s",
+			"unknown variable %s":                                                                  "variable desconocida %s",
+			"unsupported FREQ %s":                                                                  "FREQ no admitido %s",
+			"unsupported rule part %s":                                                             "parte de regla no admitida %s",
+			"until needs a date or time":                                                           "until necesita una fecha o una hora",
+			"work hours needs two timestamps":                                                      "work hours necesita dos marcas de tiempo",
+			"working time goes with dates, durations and numbers only":                             "el tiempo de trabajo solo va con fechas, duraciones y números",
+			"a time of day goes with durations and other times of day only":                        "una hora solo va con duraciones y otras horas",
+			"timecodes at different frame rates, e.g. @25fps and @30fps":                           "timecodes con distinta velocidad de fotogramas, p. ej. @25fps y @30fps",
+			"timecodes go with durations, numbers and other timecodes only":                        "los timecodes solo van con duraciones, números y otros timecodes",
+			"COUNT must be positive":                                                               "COUNT debe ser positivo",
+			"every needs a count of at least 1":                                                    "every necesita un número de al menos 1",
+			"Showing first %d dates":                                                               "Se muestran las primeras %d fechas",
+			"Use a later start or an earlier end for the rest":                                     "Para el resto, usa un inicio posterior o un final anterior",
+		},
+		units: map[string][]string{
+			"year":        {"año", "años"},
+			"month":       {"mes", "meses"},
+			"week":        {"semana", "semanas"},
+			"day":         {"día", "días"},
+			"hour":        {"hora", "horas"},
+			"minute":      {"minuto", "minutos"},
+			"second":      {"segundo", "segundos"},
+			"businessDay": {"día hábil", "días hábiles"},
+		},
+		plural: func(n float64, fraction bool) int {
+			if n == 1 && !fraction {
+				return 0
+			}
+			return 1
+		},
+		and:      "y",
+		weekdays: [7]string{"domingo", "lunes", "martes", "miércoles", "jueves", "viernes", "sábado"},
+		months:   [12]string{"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"},
+		date: func(t time.Time, weekday string, month string) string {
+			return fmt.Sprintf("%s, %d de %s de %d", weekday, t.Day(), month, t.Year())
+		},
+	},
+}
+
+// Translate message to configured language
+func tr(msg string) string {
+	if translated, ok := languages[language()].messages[msg]; ok {
+		return translated
+	}
+	return msg
+}
+
+// Unit name in the plural form matching value
+func unitName(unit string, n float64, fraction bool) string {
+	lang := languages[language()]
+
+	forms, ok := lang.units[unit]
+	if !ok {
+		return unit
+	}
+
+	i := lang.plural(math.Abs(n), fraction)
+	if i >= len(forms) {
+		i = len(forms) - 1
+	}
+	return forms[i]
+}
+
+// `Saturday, 2 March 2024` in configured language
+func formatDate(t time.Time) string {
+	lang := languages[language()]
+	return lang.date(t, lang.weekdays[t.Weekday()], lang.months[t.Month()-1])
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	uuidRegex     = regexp.MustCompile(`^(?i)[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`)
+	ulidRegex     = regexp.MustCompile(`^(?i)[0-7][0-9a-hjkmnp-tv-z]{25}$`)
+	ksuidRegex    = regexp.MustCompile(`^[0-9A-Za-z]{27}$`)
+	objectIdRegex = regexp.MustCompile(`^(?i)[0-9a-f]{24}$`)
+)
+
+// Start of the Gregorian calendar, origin of UUID v1 & v6 time
+var uuidEpoch = time.Date(1582, 10, 15, 0, 0, 0, 0, time.UTC)
+
+// KSUID seconds are counted from 2014-05-13 16:53:20 UTC
+const ksuidEpoch = 1400000000
+
+const crockfordBase32 = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+const base62 = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+// Snowflake epoch in Unix milliseconds, SNOWFLAKE_EPOCH variable
+// `twitter`, `discord` or a number of milliseconds
+func snowflakeEpoch() int64 {
+	switch e := strings.ToLower(getConfig("SNOWFLAKE_EPOCH", "twitter")); e {
+	case "twitter", "x":
+		return 1288834974657
+	case "discord":
+		return 1420070400000
+	default:
+		return Atoi(e)
+	}
+}
+
+// Creation time of an ID and what kind of ID it is, e.g. `UUID v7`
+// Times before 1990 or more than 10 years ahead are not taken as IDs,
+// so a mistyped variable name isn't read as one
+func parseID(f string) (time.Time, string, bool) {
+	t, id, ok := decodeID(f)
+	if !ok || t.Year() < 1990 || t.After(timeNow().AddDate(10, 0, 0)) {
+		return time.Time{}, "", false
+	}
+	return t, id, true
+}
+
+func decodeID(f string) (time.Time, string, bool) {
+	switch {
+	case uuidRegex.MatchString(f):
+		return parseUUID(f)
+	case ulidRegex.MatchString(f):
+		return parseULID(f)
+	case objectIdRegex.MatchString(f):
+		b, _ := hex.DecodeString(f)
+		return time.Unix(int64(binary.BigEndian.Uint32(b)), 0), "ObjectId", true
+	case ksuidRegex.MatchString(f):
+		return parseKSUID(f)
+	}
+	return time.Time{}, "", false
+}
+
+func parseUUID(f string) (time.Time, string, bool) {
+	h := strings.ReplaceAll(f, "-", "")
+	version := h[12]
+
+	var ticks uint64 // 100ns since uuidEpoch
+	switch version {
+	case '1':
+		// time_low, time_mid, version & time_hi
+		low, _ := strconv.ParseUint(h[0:8], 16, 64)
+		mid, _ := strconv.ParseUint(h[8:12], 16, 64)
+		hi, _ := strconv.ParseUint(h[13:16], 16, 64)
+		ticks = hi<<48 | mid<<32 | low
+	case '6':
+		// time_high, time_mid, version & time_low
+		high, _ := strconv.ParseUint(h[0:12], 16, 64)
+		low, _ := strconv.ParseUint(h[13:16], 16, 64)
+		ticks = high<<12 | low
+	case '7':
+		ms, _ := strconv.ParseInt(h[0:12], 16, 64)
+		return time.UnixMilli(ms), "UUID v7", true
+	default:
+		return time.Time{}, "", false
+	}
+
+	seconds, rest := int64(ticks/1e7), int64(ticks%1e7)
+	return time.Unix(uuidEpoch.Unix()+seconds, rest*100), "UUID v" + string(version), true
+}
+
+// First 10 characters are milliseconds
+func parseULID(f string) (time.Time, string, bool) {
+	var ms int64
+	for _, c := range strings.ToUpper(f[:10]) {
+		ms = ms<<5 | int64(strings.IndexRune(crockfordBase32, c))
+	}
+	return time.UnixMilli(ms), "ULID", true
+}
+
+// 20 bytes in base62, first 4 are seconds
+func parseKSUID(f string) (time.Time, string, bool) {
+	n := new(big.Int)
+	for _, c := range f {
+		n.Mul(n, big.NewInt(62))
+		n.Add(n, big.NewInt(int64(strings.IndexRune(base62, c))))
+	}
+
+	b := n.Bytes()
+	if len(b) > 20 {
+		return time.Time{}, "", false
+	}
+	b = append(make([]byte, 20-len(b)), b...)
+
+	return time.Unix(ksuidEpoch+int64(binary.BigEndian.Uint32(b)), 0), "KSUID", true
+}
+
+// `<id>sf`, milliseconds since snowflakeEpoch in the upper 42 bits
+func parseSnowflake(f string) (time.Time, bool) {
+	id, err := strconv.ParseUint(f, 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.UnixMilli(int64(id>>22) + snowflakeEpoch()), true
+}
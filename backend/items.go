@@ -2,6 +2,10 @@ package main
 
 import (
 	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
 )
 
 // Structure defining output filtering JSON for Alfred
@@ -19,133 +23,527 @@ type Icon struct {
 }
 
 type Item struct {
-	Uid          string `json:"uid,omitempty"`
-	Title        string `json:"title"`
-	Subtitle     string `json:"subtitle"`
-	Arg          string `json:"arg"`
-	Action       Action `json:"action,omitempty"`
-	QuickLookUrl string `json:"quicklookurl,omitempty"`
-	Icon         Icon   `json:"icon,omitempty"`
+	Uid          string            `json:"uid,omitempty"`
+	Title        string            `json:"title"`
+	Subtitle     string            `json:"subtitle"`
+	Arg          string            `json:"arg"`
+	Autocomplete string            `json:"autocomplete,omitempty"`
+	Valid        *bool             `json:"valid,omitempty"`
+	Variables    map[string]string `json:"variables,omitempty"`
+	Action       Action            `json:"action,omitempty"`
+	QuickLookUrl string            `json:"quicklookurl,omitempty"`
+	Icon         Icon              `json:"icon,omitempty"`
 }
 
 type outputItemFormat struct {
 	title      string
 	format     string
 	formatFunc func(dt datetime) string
+
+	// value copied, if not the one shown, e.g. without digit grouping
+	argFunc func(dt datetime) string
+
+	// names for `in`, `to` or `as`, e.g. `1h in minutes`
+	names []string
+	// shown only when asked for by name
+	hidden bool
 }
 
-func getItems(dt datetime, err error) Items {
+// Negative duration with one leading sign, e.g. `-299d, 14:10:07`
+// instead of `-299d, -14:-10:-7`
+func signed(format func(dt datetime) string) func(dt datetime) string {
+	return func(dt datetime) string {
+		if dt.ts > 0 || (dt.ts == 0 && dt.ns >= 0) {
+			return format(dt)
+		}
+		dt.ts, dt.ns = -dt.ts, -dt.ns
+		dt.year, dt.month = -dt.year, -dt.month
+		dt.updateDT(ts)
+		return "-" + format(dt)
+	}
+}
 
-	outputItemFormatsDuration := []outputItemFormat{
-		{
-			title: "Result",
-			formatFunc: func(dt datetime) string {
-				format := "%d days, %d hours, %d minutes and %d seconds"
-				return fmt.Sprintf(format, dt.day, dt.hour, dt.minute, dt.second)
-			},
+var outputItemFormatsDuration = []outputItemFormat{
+	{
+		title: "Result",
+		names: []string{"text", "words"},
+		formatFunc: signed(func(dt datetime) string {
+			if dt.year != 0 || dt.month != 0 {
+				return formatComponents(
+					[]int64{dt.year, dt.month, dt.day, dt.hour, dt.minute, dt.second},
+					[]string{"year", "month", "day", "hour", "minute", "second"})
+			}
+			return formatComponents(
+				[]int64{dt.day, dt.hour, dt.minute, dt.second},
+				[]string{"day", "hour", "minute", "second"})
+		}),
+	},
+	{
+		title: "Result (hh:mm:ss)",
+		names: []string{"hh:mm:ss"},
+		formatFunc: signed(func(dt datetime) string {
+			// total days, as dt.day of a calendar span excludes years & months
+			days := dt.ts / (24 * 3600)
+			if days == 0 {
+				format := "%02d:%02d:%02d"
+				return fmt.Sprintf(format, dt.hour, dt.minute, dt.second)
+			} else {
+				format := "%dd, %02d:%02d:%02d"
+				return fmt.Sprintf(format, days, dt.hour, dt.minute, dt.second)
+			}
+		}),
+	},
+	{
+		title: "In days",
+		names: []string{"days", "day", "d"},
+		formatFunc: func(dt datetime) string {
+			return formatUnitFloat(float64(dt.days), 2, "day")
 		},
-		{
-			title: "Result (hh:mm:ss)",
-			formatFunc: func(dt datetime) string {
-				if dt.day == 0 {
-					format := "%02d:%02d:%02d"
-					return fmt.Sprintf(format, dt.hour, dt.minute, dt.second)
-				} else {
-					format := "%dd, %02d:%02d:%02d"
-					return fmt.Sprintf(format, dt.day, dt.hour, dt.minute, dt.second)
-				}
-			},
+		argFunc: func(dt datetime) string {
+			return plainUnitFloat(float64(dt.days), 2, "day")
 		},
-		{
-			title: "In days",
-			formatFunc: func(dt datetime) string {
-				format := "%.2f days"
-				return fmt.Sprintf(format, dt.days)
-			},
+	},
+	{
+		title: "In hours",
+		names: []string{"hours", "hour", "hrs", "h"},
+		formatFunc: func(dt datetime) string {
+			return formatUnitFloat(float64(dt.hours), 2, "hour")
 		},
-		{
-			title: "In hours",
-			formatFunc: func(dt datetime) string {
-				format := "%.2f hours"
-				return fmt.Sprintf(format, dt.hours)
-			},
+		argFunc: func(dt datetime) string {
+			return plainUnitFloat(float64(dt.hours), 2, "hour")
 		},
-		{
-			title: "In minutes",
-			formatFunc: func(dt datetime) string {
-				format := "%.2f minutes"
-				return fmt.Sprintf(format, dt.minutes)
-			},
+	},
+	{
+		title: "In minutes",
+		names: []string{"minutes", "minute", "mins", "min", "m"},
+		formatFunc: func(dt datetime) string {
+			return formatUnitFloat(float64(dt.minutes), 2, "minute")
 		},
-		{
-			title: "In seconds",
-			formatFunc: func(dt datetime) string {
-				format := "%.0f seconds"
-				return fmt.Sprintf(format, dt.seconds)
-			},
+		argFunc: func(dt datetime) string {
+			return plainUnitFloat(float64(dt.minutes), 2, "minute")
 		},
-	}
+	},
+	{
+		title: "In seconds",
+		names: []string{"seconds", "second", "secs", "sec", "s"},
+		formatFunc: func(dt datetime) string {
+			if dt.ns != 0 {
+				return formatUnitFloat(dt.nanoseconds().Seconds(), 3, "second")
+			}
+			return formatUnit(dt.ts, "second")
+		},
+		argFunc: func(dt datetime) string {
+			if dt.ns != 0 {
+				return plainUnitFloat(dt.nanoseconds().Seconds(), 3, "second")
+			}
+			return plainUnit(dt.ts, "second")
+		},
+	},
+	{
+		title: "Go duration",
+		names: []string{"go", "golang"},
+		formatFunc: func(dt datetime) string {
+			return dt.nanoseconds().String()
+		},
+	},
+	{
+		title: "Prometheus",
+		names: []string{"prometheus", "prom"},
+		formatFunc: func(dt datetime) string {
+			return formatPrometheus(dt.nanoseconds())
+		},
+	},
+	{
+		title:  "Result (hh:mm)",
+		names:  []string{"hh:mm"},
+		hidden: true,
+		formatFunc: signed(func(dt datetime) string {
+			format := "%02d:%02d"
+			return fmt.Sprintf(format, dt.ts/3600, dt.minute)
+		}),
+	},
+	{
+		title:  "In weeks",
+		names:  []string{"weeks", "week", "w"},
+		hidden: true,
+		formatFunc: func(dt datetime) string {
+			return formatUnitFloat(float64(dt.days)/7, 2, "week")
+		},
+		argFunc: func(dt datetime) string {
+			return plainUnitFloat(float64(dt.days)/7, 2, "week")
+		},
+	},
+}
 
-	outputItemFormatsNumber := []outputItemFormat{
-		{
-			title: "Result",
-			formatFunc: func(dt datetime) string {
-				format := "%d"
-				return fmt.Sprintf(format, dt.ts)
-			},
+var outputItemFormatsNumber = []outputItemFormat{
+	{
+		title: "Result",
+		names: []string{"number"},
+		formatFunc: func(dt datetime) string {
+			return formatInt(dt.ts)
+		},
+		argFunc: func(dt datetime) string {
+			return strconv.FormatInt(dt.ts, 10)
+		},
+	},
+}
+
+// `90` is a number, also read as seconds
+var outputItemFormatsNumberOrDuration = append(append([]outputItemFormat{}, outputItemFormatsNumber...), outputItemFormatsDuration[1:]...)
+
+var outputItemFormatsTimestamp = append([]outputItemFormat{
+	{
+		title: "Result",
+		formatFunc: func(dt datetime) string {
+			return formatDate(dt.dt) + " " + formatClock(dt.dt) + dt.dt.Format(" MST")
+		},
+		argFunc: func(dt datetime) string {
+			return dt.dt.Format("2006-01-02 15:04:05")
+		},
+	},
+	{
+		title: "ISO 8601",
+		names: []string{"iso", "iso8601", "rfc3339"},
+		formatFunc: func(dt datetime) string {
+			return dt.dt.Format(time.RFC3339)
+		},
+	},
+	{
+		title: "Unix timestamp",
+		names: []string{"unix", "epoch", "u"},
+		formatFunc: func(dt datetime) string {
+			return formatEpoch(dt.dt, time.Second)
+		},
+	},
+	{
+		title:  "Unix timestamp (ms)",
+		names:  []string{"ums", "ms", "millis"},
+		hidden: true,
+		formatFunc: func(dt datetime) string {
+			return formatEpoch(dt.dt, time.Millisecond)
+		},
+	},
+	{
+		title:  "Unix timestamp (µs)",
+		names:  []string{"uus", "us", "micros"},
+		hidden: true,
+		formatFunc: func(dt datetime) string {
+			return formatEpoch(dt.dt, time.Microsecond)
+		},
+	},
+	{
+		title:  "Unix timestamp (ns)",
+		names:  []string{"uns", "ns", "nanos"},
+		hidden: true,
+		formatFunc: func(dt datetime) string {
+			return formatEpoch(dt.dt, time.Nanosecond)
+		},
+	},
+	{
+		title:  "Full date",
+		names:  []string{"full", "long"},
+		hidden: true,
+		formatFunc: func(dt datetime) string {
+			return formatDate(dt.dt) + " " + formatClock(dt.dt)
+		},
+	},
+	{
+		title:  "RFC 1123",
+		names:  []string{"rfc1123", "http"},
+		hidden: true,
+		formatFunc: func(dt datetime) string {
+			return dt.dt.UTC().Format(time.RFC1123)
+		},
+	},
+	{
+		title:  "Date",
+		names:  []string{"date"},
+		hidden: true,
+		formatFunc: func(dt datetime) string {
+			return dt.dt.Format("2006-01-02")
+		},
+	},
+	{
+		title:  "Time",
+		names:  []string{"time", "clock"},
+		hidden: true,
+		formatFunc: func(dt datetime) string {
+			return formatClock(dt.dt)
+		},
+	},
+}, epochOutputItemFormats()...)
+
+var outputItemFormatsTimeOfDay = []outputItemFormat{
+	{
+		title:      "Result",
+		formatFunc: formatTimeOfDay,
+	},
+	{
+		title: "24-hour clock",
+		names: []string{"24h"},
+		formatFunc: func(dt datetime) string {
+			return clockTime(dt.ts).Format("15:04:05")
+		},
+	},
+	{
+		title: "12-hour clock",
+		names: []string{"12h", "ampm"},
+		formatFunc: func(dt datetime) string {
+			return clockTime(dt.ts).Format("3:04:05 PM")
+		},
+	},
+	{
+		title: "Since midnight",
+		names: []string{"midnight"},
+		formatFunc: func(dt datetime) string {
+			return formatUnitFloat(float64(floorMod(dt.ts, secondsPerDay))/3600, 2, "hour")
+		},
+	},
+	{
+		title: "Date and time",
+		names: []string{"today", "timestamp", "full", "long"},
+		formatFunc: func(dt datetime) string {
+			t := midnight(timeNow()).Add(time.Duration(dt.ts) * time.Second)
+			return formatDate(t) + " " + formatClock(t)
+		},
+	},
+	{
+		title:  "Time",
+		names:  []string{"time", "clock"},
+		hidden: true,
+		formatFunc: func(dt datetime) string {
+			return formatClock(clockTime(dt.ts))
+		},
+	},
+}
+
+var outputItemFormatsTimecode = []outputItemFormat{
+	{
+		title:      "Result",
+		names:      []string{"timecode", "tc", "smpte"},
+		formatFunc: formatTimecode,
+	},
+	{
+		title: "Total frames",
+		names: []string{"frames"},
+		formatFunc: func(dt datetime) string {
+			return formatInt(dt.ts)
+		},
+		argFunc: func(dt datetime) string {
+			return strconv.FormatInt(dt.ts, 10)
+		},
+	},
+	{
+		title: "In seconds",
+		names: []string{"seconds", "second", "secs", "sec", "s"},
+		formatFunc: func(dt datetime) string {
+			return formatUnitFloat(dt.timecodeSeconds(), 3, "second")
+		},
+		argFunc: func(dt datetime) string {
+			return plainUnitFloat(dt.timecodeSeconds(), 3, "second")
+		},
+	},
+	{
+		title: "Result (hh:mm:ss)",
+		names: []string{"hh:mm:ss"},
+		formatFunc: func(dt datetime) string {
+			seconds, sign := dt.timecodeSeconds(), ""
+			if seconds < 0 {
+				seconds, sign = -seconds, "-"
+			}
+			s := int64(math.Floor(seconds))
+			return fmt.Sprintf("%s%02d:%02d:%02d", sign, s/3600, s%3600/60, s%60)
 		},
+	},
+	{
+		title:      "Frame rate",
+		names:      []string{"fps"},
+		formatFunc: formatFrameRate,
+	},
+}
+
+var outputItemFormatsBusinessDays = []outputItemFormat{
+	{
+		title: "Result",
+		formatFunc: func(dt datetime) string {
+			return formatUnit(dt.ts, "businessDay")
+		},
+		argFunc: func(dt datetime) string {
+			return plainUnit(dt.ts, "businessDay")
+		},
+	},
+}
+
+var outputItemFormatsMoney = []outputItemFormat{
+	{
+		title: "Result",
+		formatFunc: func(dt datetime) string {
+			return formatCents(dt.ts)
+		},
+		argFunc: func(dt datetime) string {
+			return plainCents(dt.ts)
+		},
+	},
+	{
+		title: "Amount",
+		names: []string{"amount", "money"},
+		formatFunc: func(dt datetime) string {
+			return formatMoney(dt.ts)
+		},
+	},
+}
+
+// Rate in another unit, empty if it's the rate's own
+func formatRateIn(dt datetime, unit string) string {
+	if dt.per == unit {
+		return ""
 	}
+	return formatMoney(divRound(dt.ts*rateSeconds(unit), rateSeconds(dt.per))) + "/" + unit
+}
 
-	outputItemFormatsTimestamp := []outputItemFormat{
-		{
-			title: "Result",
-			formatFunc: func(dt datetime) string {
-				format := "%v"
-				return fmt.Sprintf(format, dt.dt)
-			},
+var outputItemFormatsRate = []outputItemFormat{
+	{
+		title: "Result",
+		formatFunc: func(dt datetime) string {
+			return formatMoney(dt.ts) + "/" + dt.per
+		},
+	},
+	{
+		title: "Per hour",
+		names: []string{"hourly"},
+		formatFunc: func(dt datetime) string {
+			return formatRateIn(dt, "h")
 		},
+	},
+	{
+		title: "Per day",
+		names: []string{"daily"},
+		formatFunc: func(dt datetime) string {
+			return formatRateIn(dt, "d")
+		},
+	},
+}
+
+// Output formats for given kind of result
+func outputItemFormats(kind int) []outputItemFormat {
+	if kind == number {
+		return outputItemFormatsNumber
+	} else if kind == number|duration {
+		return outputItemFormatsNumberOrDuration
+	} else if kind == duration {
+		return outputItemFormatsDuration
+	} else if kind == timestamp {
+		return outputItemFormatsTimestamp
+	} else if kind == timeOfDay {
+		return outputItemFormatsTimeOfDay
+	} else if kind == timecode {
+		return outputItemFormatsTimecode
+	} else if kind == jwt|timestamp {
+		return outputItemFormatsJWT
+	} else if kind == businessDays {
+		return outputItemFormatsBusinessDays
+	} else if kind == workTime {
+		return outputItemFormatsDuration
+	} else if kind == money {
+		return outputItemFormatsMoney
+	} else if kind == rate {
+		return outputItemFormatsRate
+	}
+	return nil
+}
+
+// The "Result" line for given value, used wherever only one line fits
+// or the format asked for with `in`, `to` or `as`
+func formatResult(dt datetime) string {
+	formats := outputItemFormats(dt.kind)
+	if i, ok := findOutputFormat(dt.kind, dt.format); ok {
+		return formats[i].formatFunc(dt)
+	} else if len(formats) == 0 {
+		return ""
+	}
+	return formats[0].formatFunc(dt)
+}
+
+// Index of output format of given kind by one of its names
+func findOutputFormat(kind int, name string) (int, bool) {
+	name = strings.ToLower(name)
+
+	for i, v := range outputItemFormats(kind) {
+		for _, n := range v.names {
+			if n == name {
+				return i, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// Any kind has output format of that name
+func isOutputFormat(name string) bool {
+	for _, kind := range []int{number, duration, timestamp, timeOfDay, jwt | timestamp, timecode, businessDays, workTime, rate, money} {
+		if _, ok := findOutputFormat(kind, name); ok {
+			return true
+		}
 	}
+	return false
+}
+
+// One item per output format of the result
+// The one asked for with `in`, `to` or `as` goes first
+func resultItems(dt datetime) []Item {
+	var items []Item
+
+	requested, ok := findOutputFormat(dt.kind, dt.format)
+	// e.g. FILETIME of `133538940000000000ft`
+	epoch, shown := findOutputFormat(dt.kind, dt.epoch)
+
+	for i, v := range outputItemFormats(dt.kind) {
+		if v.hidden && !(ok && i == requested) && !(shown && i == epoch) {
+			continue
+		}
+
+		// e.g. a claim missing from a JWT
+		subtitle := v.formatFunc(dt)
+		if subtitle == "" {
+			continue
+		}
+
+		item := Item{
+			Title:    tr(v.title),
+			Subtitle: subtitle,
+			Arg:      subtitle,
+		}
+		if v.argFunc != nil {
+			item.Arg = v.argFunc(dt)
+		}
 
+		// e.g. how the number of digits of an epoch was read,
+		// kept on the result, also when a requested format is shown first
+		if v.title == "Result" && dt.note != "" {
+			item.Subtitle += " (" + dt.note + ")"
+		}
+
+		if ok && i == requested {
+			items = append([]Item{item}, items...)
+		} else {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func getItems(dt datetime, err error) Items {
 	items := Items{
 		Skipknowldedge: true,
 	}
 
 	// Skip any output if error
 	if err == nil {
-		if dt.kind == number {
-			for _, v := range outputItemFormatsNumber {
-				item := Item{
-					Title:    fmt.Sprintf("%s", v.title),
-					Subtitle: v.formatFunc(dt),
-					Arg:      v.formatFunc(dt),
-				}
-				items.Items = append(items.Items, item)
-			}
-		} else if dt.kind == duration {
-
-			for _, v := range outputItemFormatsDuration {
-				item := Item{
-					Title:    fmt.Sprintf("%s", v.title),
-					Subtitle: v.formatFunc(dt),
-					Arg:      v.formatFunc(dt),
-				}
-				items.Items = append(items.Items, item)
-			}
-		} else if dt.kind == timestamp {
-			for _, v := range outputItemFormatsTimestamp {
-				item := Item{
-					Title:    fmt.Sprintf("%s", v.title),
-					Subtitle: v.formatFunc(dt),
-					Arg:      v.formatFunc(dt),
-				}
-				items.Items = append(items.Items, item)
-			}
-		}
-
+		items.Items = append(items.Items, resultItems(dt)...)
 	} else {
 		item := Item{
 			Uid:      "Error",
-			Title:    "Input error!",
+			Title:    tr("Input error!"),
 			Subtitle: err.Error(),
 			Arg:      "error",
 			Action: Action{
@@ -157,7 +555,7 @@ func getItems(dt datetime, err error) Items {
 
 	item := Item{
 		Uid:      "_XBuy a Coffee",
-		Title:    "Support my work and Buy me a Coffee!",
+		Title:    tr("Support my work and Buy me a Coffee!"),
 		Subtitle: "TimeDiff workflow by Jarek Hartman",
 		Arg:      "open",
 		Action: Action{
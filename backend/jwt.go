@@ -0,0 +1,160 @@
+package main
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var jwtRegex = regexp.MustCompile(`^[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*$`)
+
+// Registered time claims of a JWT
+type jwtClaims struct {
+	IssuedAt  *float64 `json:"iat"`
+	NotBefore *float64 `json:"nbf"`
+	Expires   *float64 `json:"exp"`
+}
+
+// Payload of a JWT, the signature is not verified
+func decodeJWT(token string) (jwtClaims, bool) {
+	var claims jwtClaims
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return claims, false
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return claims, false
+	}
+
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return claims, false
+	}
+	return claims, claims.IssuedAt != nil || claims.NotBefore != nil || claims.Expires != nil
+}
+
+func claimTime(claim *float64) time.Time {
+	return time.Unix(int64(*claim), 0)
+}
+
+// JWT as operand is its expiry time, or issue time if it doesn't expire
+func parseJWT(f string, dt *datetime) {
+	claims, ok := decodeJWT(f)
+	if !ok {
+		return
+	}
+
+	if claims.Expires != nil {
+		dt.dt = claimTime(claims.Expires)
+	} else if claims.IssuedAt != nil {
+		dt.dt = claimTime(claims.IssuedAt)
+	} else {
+		dt.dt = claimTime(claims.NotBefore)
+	}
+	dt.token = f
+	dt.kind = jwt | timestamp
+}
+
+// `3h`, `1d 4h`, `12m 5s`, two largest units only
+func formatRelative(d time.Duration) string {
+	s := int64(d.Abs().Seconds())
+
+	values := []int64{s / (24 * 3600), s % (24 * 3600) / 3600, s % 3600 / 60, s % 60}
+	units := []string{"d", "h", "m", "s"}
+
+	for i, v := range values {
+		if v == 0 {
+			continue
+		}
+
+		result := fmt.Sprintf("%d%s", v, units[i])
+		if i+1 < len(values) && values[i+1] != 0 {
+			result += fmt.Sprintf(" %d%s", values[i+1], units[i+1])
+		}
+		return result
+	}
+	return "0s"
+}
+
+// `expires in 12m`, `expired 3h ago`
+func formatExpiry(exp time.Time) string {
+	if d := exp.Sub(timeNow()); d > 0 {
+		return fmt.Sprintf(tr("expires in %s"), formatRelative(d))
+	} else {
+		return fmt.Sprintf(tr("expired %s ago"), formatRelative(d))
+	}
+}
+
+// `issued 2h ago`, `not valid for 5m`, ...
+func formatSince(t time.Time, past string, future string) string {
+	if d := timeNow().Sub(t); d >= 0 {
+		return fmt.Sprintf(tr(past), formatRelative(d))
+	} else {
+		return fmt.Sprintf(tr(future), formatRelative(d))
+	}
+}
+
+func formatClaim(claim *float64, relative func(t time.Time) string) string {
+	if claim == nil {
+		return ""
+	}
+	t := claimTime(claim)
+	return formatDate(t) + " " + formatClock(t) + " (" + relative(t) + ")"
+}
+
+var outputItemFormatsJWT = []outputItemFormat{
+	{
+		title: "Result",
+		formatFunc: func(dt datetime) string {
+			claims, _ := decodeJWT(dt.token)
+			if claims.Expires == nil {
+				return tr("never expires")
+			}
+			return formatExpiry(claimTime(claims.Expires))
+		},
+	},
+	{
+		title: "Expires",
+		names: []string{"exp", "expires"},
+		formatFunc: func(dt datetime) string {
+			claims, _ := decodeJWT(dt.token)
+			return formatClaim(claims.Expires, formatExpiry)
+		},
+	},
+	{
+		title: "Issued at",
+		names: []string{"iat", "issued"},
+		formatFunc: func(dt datetime) string {
+			claims, _ := decodeJWT(dt.token)
+			return formatClaim(claims.IssuedAt, func(t time.Time) string {
+				return formatSince(t, "issued %s ago", "issued in %s")
+			})
+		},
+	},
+	{
+		title: "Not before",
+		names: []string{"nbf"},
+		formatFunc: func(dt datetime) string {
+			claims, _ := decodeJWT(dt.token)
+			return formatClaim(claims.NotBefore, func(t time.Time) string {
+				return formatSince(t, "valid since %s ago", "valid in %s")
+			})
+		},
+	},
+	{
+		title: "Lifetime",
+		names: []string{"lifetime", "ttl"},
+		formatFunc: func(dt datetime) string {
+			claims, _ := decodeJWT(dt.token)
+			if claims.Expires == nil || claims.IssuedAt == nil {
+				return ""
+			}
+			return formatRelative(claimTime(claims.Expires).Sub(claimTime(claims.IssuedAt)))
+		},
+	},
+}
@@ -4,15 +4,37 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"strings"
 )
 
 const buymeacoffee = "https://www.buymeacoffee.com/jhartman"
 
 func getAlfredJson(p string) string {
-	var items Items
+	items, ok := runCommand(p)
+	if !ok && strings.TrimSpace(p) == "" {
+		items, ok = historyItems()
+	}
+	if !ok {
+		dt, err := evaluate(p, loadEnvironment())
+		items = getItems(dt, err)
 
-	dt, err := parse(p)
-	items = getItems(dt, err)
+		if err == nil {
+			for i := range items.Items {
+				if items.Items[i].Uid == "" {
+					items.Items[i].Variables = map[string]string{historyVariable: p}
+				}
+			}
+		}
+
+		if name, _, ok := parseAssignment(p); ok && err == nil {
+			item := Item{
+				Title:    fmt.Sprintf(tr("Save as %s"), name),
+				Subtitle: fmt.Sprintf("%s = %s", name, formatResult(dt)),
+				Arg:      actionPrefix + "let " + p,
+			}
+			items.Items = append([]Item{item}, items.Items...)
+		}
+	}
 
 	b, err := json.MarshalIndent(items, "", "  ")
 	if err == nil {
@@ -37,6 +59,12 @@ func getAlfredJson(p string) string {
 func main() {
 	var input string
 
+	// Item actioned in Alfred
+	if len(os.Args) == 3 && os.Args[1] == "--action" {
+		fmt.Print(runAction(os.Args[2]))
+		return
+	}
+
 	if len(os.Args) != 2 {
 		// No parameters
 		input = ""
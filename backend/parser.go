@@ -2,6 +2,7 @@ package main
 
 import (
 	"errors"
+	"fmt"
 	"regexp"
 	"strconv"
 	"strings"
@@ -16,6 +17,13 @@ const (
 	div
 )
 
+var operations = map[string]int{
+	"+": add,
+	"-": sub,
+	"*": mul,
+	"/": div,
+}
+
 // source
 // when invoking updateDT, what is the "source of truth"
 // to update other fields
@@ -38,18 +46,36 @@ const (
 	timestamp = 1 << (iota - 1)
 	duration
 	number
+	businessDays // dt.ts is the number of days
+	workTime     // duration counted in working hours only
+	rate         // dt.ts is in cents per dt.per
+	money        // dt.ts is in cents
+	timeOfDay    // dt.ts is seconds since midnight, see newTimeOfDay
+	jwt          // with timestamp, dt.token is a JWT, dt.dt its expiry
+	timecode     // dt.ts is the number of frames at dt.fps
 )
 
 type datetime struct {
 	kind                          int
 	parameter                     string
+	format                        string  // output format asked for, e.g. `in hours`
+	note                          string  // how the input was read, e.g. `13 digits, read as milliseconds`
+	token                         string  // JWT, see parseJWT
+	epoch                         string  // epoch system of the input, its output item is shown, e.g. `ft`
+	per                           string  // unit of a rate, `h`, `m` or `d`
+	fps                           float64 // frame rate of a timecode
+	dropFrame                     bool
 	dt                            time.Time
 	ts                            int64 // no of seconds
-	day, month, year              int64
+	ns                            int64 // and nanoseconds of a duration, e.g. `350ms`
+	day, month, year              int64 // year & month set only for calendar spans
 	hour, minute, second          int64
 	days, hours, minutes, seconds float32
 }
 
+// Current time, replaced in tests
+var timeNow = time.Now
+
 // Helpers
 
 func Atof(f string) float32 {
@@ -68,6 +94,16 @@ func Atoi(f string) int64 {
 	}
 }
 
+// Duration of given number of seconds
+func newDuration(s int64) datetime {
+	dt := datetime{
+		kind: duration,
+		ts:   s,
+	}
+	dt.updateDT(ts)
+	return dt
+}
+
 func (dt *datetime) updateDT(source int) {
 
 	var s int64
@@ -103,15 +139,47 @@ func (dt *datetime) updateDT(source int) {
 	dt.dt = time.Date(int(dt.year), time.Month(dt.month), int(dt.day), int(dt.hour), int(dt.minute), int(dt.second), 0, time.UTC)
 }
 
-func (dt *datetime) calculateDT(dt1 datetime, dt2 datetime, operation int) {
-	if operation == add {
+// Result of dt1 <operation> dt2, kind is left unset if the kinds don't go together
+func (dt *datetime) calculateDT(dt1 datetime, dt2 datetime, operation int) error {
+	if dt1.kind == businessDays || dt2.kind == businessDays {
+		return dt.calculateBusinessDays(dt1, dt2, operation)
+	}
+
+	if dt1.kind&(rate|money) != 0 || dt2.kind&(rate|money) != 0 {
+		return dt.calculateMoney(dt1, dt2, operation)
+	}
+
+	if dt1.kind == workTime || dt2.kind == workTime {
+		return dt.calculateWorkTime(dt1, dt2, operation)
+	}
+
+	if dt1.kind == timecode || dt2.kind == timecode {
+		return dt.calculateTimecode(dt1, dt2, operation)
+	}
+
+	if dt1.kind == timeOfDay || dt2.kind == timeOfDay {
+		return dt.calculateTimeOfDay(dt1, dt2, operation)
+	}
+
+	if (operation == add || operation == sub) && (dt1.kind&number != 0) && (dt2.kind&number != 0) {
+		// 10/2 + 3 -> 8, a number unless both can be read as seconds
+		dt.kind = dt1.kind & dt2.kind
+		dt.ts = dt1.ts + dt2.ts
+		dt.ns = dt1.ns + dt2.ns
+		if operation == sub {
+			dt.ts = dt1.ts - dt2.ts
+			dt.ns = dt1.ns - dt2.ns
+		}
+	} else if operation == add {
 
 		if dt1.kind == dt2.kind {
 			dt.kind = dt1.kind
 			dt.ts = dt1.ts + dt2.ts
+			dt.ns = dt1.ns + dt2.ns
 		} else if (dt1.kind & duration) == (dt2.kind & duration) {
 			dt.kind = duration
 			dt.ts = dt1.ts + dt2.ts
+			dt.ns = dt1.ns + dt2.ns
 		} else if (dt1.kind&timestamp != 0) && (dt2.kind&duration != 0) {
 			dt.kind = timestamp
 			dt.dt = dt1.dt
@@ -119,20 +187,62 @@ func (dt *datetime) calculateDT(dt1 datetime, dt2 datetime, operation int) {
 			dt.dt = dt.dt.Add(time.Minute * time.Duration(dt2.minute))
 			dt.dt = dt.dt.Add(time.Hour * time.Duration(dt2.hour))
 			dt.dt = dt.dt.Add(time.Hour * time.Duration(dt2.day*24))
-			return
+			dt.dt = dt.dt.Add(time.Duration(dt2.ns))
+			return nil
+		} else if (dt1.kind&duration != 0) && (dt2.kind&timestamp != 0) {
+			return dt.calculateDT(dt2, dt1, add)
 		}
 	} else if operation == sub {
-		dt.ts = dt1.ts - dt2.ts
-		if (dt1.kind & duration) == (dt2.kind & duration) {
+		if (dt1.kind&timestamp != 0) && (dt2.kind&timestamp != 0) {
+			// 24/12 - now -> duration
 			dt.kind = duration
+			dt.ts = int64(dt1.dt.Sub(dt2.dt).Seconds())
+			dt.ns = int64(dt1.dt.Sub(dt2.dt) % time.Second)
+		} else if (dt1.kind&timestamp != 0) && (dt2.kind&duration != 0) {
+			// now - 1h -> timestamp
+			dt.kind = timestamp
+			dt.dt = dt1.dt.Add(-time.Second*time.Duration(dt2.ts) - time.Duration(dt2.ns))
+			return nil
+		} else {
+			dt.ts = dt1.ts - dt2.ts
+			dt.ns = dt1.ns - dt2.ns
+			if (dt1.kind & duration) == (dt2.kind & duration) {
+				dt.kind = duration
+			}
 		}
 	} else if operation == mul {
 		dt.ts = dt1.ts * dt2.ts
-		if (dt1.kind & duration) == (dt2.kind & number) {
+		if (dt1.kind&number != 0) && (dt2.kind&number != 0) {
+			// 2 * 3 -> 6
+			dt.kind = dt1.kind & dt2.kind
+		} else if (dt1.kind == duration) && (dt2.kind&number != 0) {
+			// 1h * 2 -> 2h
+			// (duration) * (number) = (duration)
 			dt.kind = duration
+			dt.ns = dt1.ns * dt2.ts
+		} else if (dt1.kind&number != 0) && (dt2.kind == duration) {
+			// 2 * 1h -> 2h
+			// (number) * (duration) = (duration)
+			dt.kind = duration
+			dt.ns = dt1.ts * dt2.ns
 		}
 	} else if operation == div {
-		if dt1.ts != 0 {
+		if dt1.kind&(number|duration) == 0 || dt2.kind&(number|duration) == 0 {
+			// now / 1h, kind left unset
+			return nil
+		} else if dt2.ts == 0 && dt2.ns == 0 {
+			return errors.New(tr("division by zero"))
+		}
+
+		if dt1.ns != 0 || dt2.ns != 0 || (dt1.kind == duration && dt2.kind&number != 0) {
+			// 1s / 4 -> 250ms, 1s / 250ms -> 4
+			if dt2.kind&number != 0 {
+				d := dt1.nanoseconds() / time.Duration(dt2.ts)
+				dt.ts, dt.ns = int64(d/time.Second), int64(d%time.Second)
+			} else if dt2.nanoseconds() != 0 {
+				dt.ts = int64(dt1.nanoseconds() / dt2.nanoseconds())
+			}
+		} else if dt1.ts != 0 {
 			dt.ts = dt1.ts / dt2.ts
 		} else {
 			dt.ts = dt1.ts
@@ -172,9 +282,11 @@ func (dt *datetime) calculateDT(dt1 datetime, dt2 datetime, operation int) {
 		// }
 	}
 
+	dt.normalizeNanoseconds()
 	dt.updateDT(ts)
 
 	// dt.dt = time.Now()
+	return nil
 }
 
 // Try to guess field format.
@@ -224,6 +336,13 @@ func parseField(f string, dt *datetime) error {
 				dt.updateDT(ymdhms)
 			},
 		},
+		//   - `<hh:mm:ss:ff>` SMPTE timecode, `<hh:mm:ss;ff>` drop-frame
+		//     optionally followed by `@<fps>`, see applyFrameRate
+		{
+			regex:          `^([0-9]{2}):([0-9]{2}):([0-9]{2})([:;])([0-9]{2})(?:@([0-9]+(?:\.[0-9]+)?)(?:fps)?)?$`,
+			noOfParameters: 6,
+			parserFunc:     parseTimecode,
+		},
 		//   - `<hh:mm:ss>`
 		{
 			regex:          `^([0-9]+):([0-9]+):([0-9]+)$`,
@@ -237,23 +356,164 @@ func parseField(f string, dt *datetime) error {
 				dt.updateDT(ymdhms)
 			},
 		},
+		//   - `<DD>/<MM>/<YYYY>`, `<YYYY>-<MM>-<DD>`
+		//     optionally followed by ` <hh:mm>` or ` <hh:mm:ss>`
+		//     `<DD>/<MM>` is a division, see evaluateDate
 		{
-			regex:          `^([0-9]+)u$`,
-			noOfParameters: 1,
+			regex:          `^([0-9/-]+)( [0-9:]+(?: ?(?i:[ap]\.?m\.?))?)?$`,
+			noOfParameters: 2,
 			parserFunc: func(match []string, dt *datetime) {
-				i := Atoi(match[1])
-				dt.dt = time.Unix(i, 0)
-				dt.kind = timestamp
+				if t, ok := parseDate(match[0]); ok && !isPartialDate(match[0]) {
+					dt.dt = t
+					dt.kind = timestamp
+				}
+			},
+		},
+		//   - `<weekday>`, e.g. `fri` or `friday`, the next one after today
+		//     optionally followed by ` <hh:mm>` or ` <hh:mm:ss>`
+		{
+			regex:          `^(?i)(mon|tue|wed|thu|fri|sat|sun)[a-z]*( [0-9:]+(?: ?(?i:[ap]\.?m\.?))?)?$`,
+			noOfParameters: 2,
+			parserFunc: func(match []string, dt *datetime) {
+				if t, ok := parseDate(match[0]); ok {
+					dt.dt = t
+					dt.kind = timestamp
+				}
 			},
 		},
+		//   - `<clock>..<clock>`, duration between clock times
+		//     also `from <clock> to <clock>`, see tokenize
 		{
-			regex:          `now`,
+			regex:          `^\S+\.\.\S+$`,
 			noOfParameters: 0,
 			parserFunc: func(match []string, dt *datetime) {
-				dt.dt = time.Now().Round(time.Second)
+				if s, ok := parseClockRange(match[0]); ok {
+					*dt = newDuration(s)
+				}
+			},
+		},
+		//   - `<h>am`, `<h:mm>pm`, `<h:mm:ss> PM`, time of day
+		//   - `@<hh:mm>`, `@<hh:mm:ss>`, time of day on 24-hour clock
+		{
+			regex:          `^(?i)(?:@[0-9]{1,2}(?::[0-9]{2}){1,2}|@?[0-9]{1,2}(?::[0-9]{2}){0,2} ?[ap]\.?m\.?)$`,
+			noOfParameters: 0,
+			parserFunc: func(match []string, dt *datetime) {
+				if offset, ok := parseClock(strings.TrimPrefix(match[0], "@")); ok {
+					*dt = newTimeOfDay(int64(offset.Seconds()))
+				}
+			},
+		},
+		//   - `<n>u` Unix epoch, unit guessed from the number of digits
+		//   - `<n>ums`, `<n>uus`, `<n>uns` in milli-, micro- and nanoseconds
+		{
+			regex:          `^([0-9]+)(u|ums|uus|uµs|uns)$`,
+			noOfParameters: 2,
+			parserFunc: func(match []string, dt *datetime) {
+				parseEpoch(match[1], match[2], dt)
+			},
+		},
+		//   - `<n>ft`, `<n>ticks`, `<n>cocoa`, `<n>xl`, `<n>gps`, `<n>webkit`, `<n>hfs`, see epochSystems
+		{
+			regex:          `^(?i)([0-9]+(?:\.[0-9]+)?)(ft|ldap|ticks|cocoa|xl|lotus|gps|webkit|chrome|hfs)$`,
+			noOfParameters: 2,
+			parserFunc: func(match []string, dt *datetime) {
+				if e, ok := findEpochSystem(match[2]); ok {
+					if t, ok := e.time(match[1]); ok {
+						dt.dt = t
+						dt.kind = timestamp
+						dt.epoch = strings.ToLower(match[2])
+					}
+				}
+			},
+		},
+		{
+			regex:          `^now$`,
+			noOfParameters: 0,
+			parserFunc: func(match []string, dt *datetime) {
+				dt.dt = timeNow().Round(time.Second)
 				dt.kind = timestamp
 			},
 		},
+		//   - `<n>bd`, business days
+		{
+			regex:          `^([0-9]+)bd$`,
+			noOfParameters: 1,
+			parserFunc: func(match []string, dt *datetime) {
+				dt.ts = Atoi(match[1])
+				dt.kind = businessDays
+			},
+		},
+		//   - `<n>/h`, `<n>/m`, `<n>/d` rate
+		{
+			regex:          `^[0-9.]+/[hmd]$`,
+			noOfParameters: 0,
+			parserFunc: func(match []string, dt *datetime) {
+				if cents, unit, ok := parseRate(match[0]); ok {
+					dt.ts = cents
+					dt.per = unit
+					dt.kind = rate
+				}
+			},
+		},
+		//   - `<n>wh`, `<n>wm` working hours & minutes
+		//     also `<n> work hours`, see tokenize
+		{
+			regex:          `^([0-9]+)w(h|m)$`,
+			noOfParameters: 2,
+			parserFunc: func(match []string, dt *datetime) {
+				dt.ts = Atoi(match[1]) * 60
+				if match[2] == "h" {
+					dt.ts *= 60
+				}
+				dt.kind = workTime
+				dt.updateDT(ts)
+			},
+		},
+		//   - `<header>.<payload>.<signature>` JWT, see parseJWT
+		{
+			regex:          `^[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*$`,
+			noOfParameters: 0,
+			parserFunc: func(match []string, dt *datetime) {
+				parseJWT(match[0], dt)
+			},
+		},
+		//   - UUID v1/v6/v7, ULID, KSUID or ObjectId, creation time
+		{
+			regex:          `^[0-9A-Za-z-]{24,36}$`,
+			noOfParameters: 0,
+			parserFunc: func(match []string, dt *datetime) {
+				if t, id, ok := parseID(match[0]); ok {
+					dt.dt = t
+					dt.note = id
+					dt.kind = timestamp
+				}
+			},
+		},
+		//   - `<id>sf` Snowflake ID, creation time
+		{
+			regex:          `^([0-9]+)sf$`,
+			noOfParameters: 1,
+			parserFunc: func(match []string, dt *datetime) {
+				if t, ok := parseSnowflake(match[1]); ok {
+					dt.dt = t
+					dt.note = "Snowflake"
+					dt.kind = timestamp
+				}
+			},
+		},
+		//   - Go, Prometheus and systemd durations, e.g. `1h2m3.5s`, `350ms`, `1w2d`
+		{
+			regex:          `^(?:[0-9]+(?:\.[0-9]+)?(?:ms|us|µs|ns|y|w|d|h|m|s))+$`,
+			noOfParameters: 0,
+			parserFunc: func(match []string, dt *datetime) {
+				if s, ns, ok := parseDurationDialect(match[0]); ok {
+					dt.ts = s
+					dt.ns = ns
+					dt.kind = duration
+					dt.updateDT(ts)
+				}
+			},
+		},
 		// Passers below needs to be ad the end
 		// to support fields like 1d1h1s
 		//   - `<d+>d`
@@ -317,6 +577,11 @@ func parseField(f string, dt *datetime) error {
 			//  - day, hour, minute, second
 			p.parserFunc(match, dt)
 
+			// parserFunc may reject the match, e.g. 31/02
+			if dt.kind == none {
+				continue
+			}
+
 			if !p.parseNext {
 				return nil
 			}
@@ -332,74 +597,251 @@ func parseField(f string, dt *datetime) error {
 	}
 }
 
+// Fields containing operator characters which are not operators
+var protectedFields = []func(f string) bool{
+	func(f string) bool {
+		_, ok := parseDate(f)
+		return ok && !isPartialDate(f)
+	},
+	rateRegex.MatchString,
+	uuidRegex.MatchString,
+	jwtRegex.MatchString,
+}
+
+// `<hh:mm>`, `<hh:mm:ss>` or 12-hour `<h>am`, `<h:mm>pm`, `<h:mm:ss> PM`
+var clockRegex = regexp.MustCompile(`^([0-9]{1,2})(?::([0-9]{2}))?(?::([0-9]{2}))?(?: ?(?i:([ap])\.?m\.?))?$`)
+
+func isClock(f string) bool {
+	_, ok := parseClock(f)
+	return ok
+}
+
+// `6 work hours` -> `6wh`, `30 working minutes` -> `30wm`
+var workTimeRegex = regexp.MustCompile(`(?i)\b([0-9]+) ?work(?:ing)? ?(h|m)(?:ours?|inutes?|rs?|ins?)?\b`)
+
+// Split input into fields and operators
+func tokenize(p string) []string {
+	var fields []string
+
+	p = workTimeRegex.ReplaceAllString(p, "${1}w${2}")
+	p = normalizeClockRanges(p)
+	p = normalizeDurationWords(p)
+	p = applyFrameRate(p)
+
+next:
+	for _, f := range strings.Fields(p) {
+		for _, protected := range protectedFields {
+			if protected(f) {
+				fields = append(fields, f)
+				continue next
+			}
+		}
+
+		// As later input string will be split using space(s)
+		// ensure there IS at least one space around the operator
+		// So accept: 3+4 or 3 + 4
+		for _, c := range []string{"+", "-", "*", "/"} {
+			f = strings.ReplaceAll(f, c, " "+c+" ")
+		}
+		fields = append(fields, strings.Fields(f)...)
+	}
+
+	// `9:30 PM` is a single field
+	for i := 1; i < len(fields); i++ {
+		if meridiemRegex.MatchString(fields[i]) && clockRegex.MatchString(fields[i-1]) {
+			fields[i-1] += " " + fields[i]
+			fields = append(fields[:i], fields[i+1:]...)
+		}
+	}
+
+	// `1m 30s` is a single field, `1h 2h` are two durations
+	for i := 1; i < len(fields); i++ {
+		if durationDialectRegex.MatchString(fields[i-1]) && durationDialectRegex.MatchString(fields[i]) && unitsDescend(fields[i-1], fields[i]) {
+			fields[i-1] += fields[i]
+			fields = append(fields[:i], fields[i+1:]...)
+			i--
+		}
+	}
+
+	// `<date> <time>` is a single field
+	for i := 0; i+1 < len(fields); i++ {
+		if _, ok := parseDate(fields[i]); ok && !isPartialDate(fields[i]) && isClock(fields[i+1]) {
+			fields[i] += " " + fields[i+1]
+			fields = append(fields[:i+1], fields[i+2:]...)
+		}
+	}
+	return fields
+}
+
+// Variables defined with `<name> = <expr>`
+type environment map[string]datetime
+
+var identifierRegex = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// Names which can't be used for variables
+var reservedNames = map[string]bool{
+	"now":   true,
+	"until": true,
+	"age":   true,
+	"since": true,
+	"round": true,
+	"in":    true,
+	"to":    true,
+	"as":    true,
+
+	// commands, see commands
+	"week":     true,
+	"sprint":   true,
+	"vars":     true,
+	"timers":   true,
+	"holidays": true,
+	"cron":     true,
+	"rrule":    true,
+	"every":    true,
+	"start":    true,
+	"lap":      true,
+	"stop":     true,
+	"reset":    true,
+	"del":      true,
+	"let":      true,
+	"bd":       true,
+	"workdays": true,
+}
+
+func isIdentifier(f string) bool {
+	return identifierRegex.MatchString(f)
+}
+
+// Parse single operand, either a variable from env or a field
+func parseOperand(f string, env environment, dt *datetime) error {
+	if v, ok := env[f]; ok {
+		parameter := dt.parameter
+		*dt = v
+		dt.parameter = parameter
+		return nil
+	}
+
+	if err := parseField(f, dt); err != nil {
+		if isIdentifier(f) {
+			return fmt.Errorf(tr("unknown variable %s"), f)
+		}
+		return fmt.Errorf(tr("cannot parse %s"), f)
+	}
+	return nil
+}
+
+// Split `<name> = <expr>` into name and expression
+func parseAssignment(p string) (string, string, bool) {
+	name, expr, found := strings.Cut(p, "=")
+	if !found {
+		return "", "", false
+	}
+
+	name = strings.TrimSpace(name)
+	if !isIdentifier(name) {
+		return "", "", false
+	}
+
+	return name, strings.TrimSpace(expr), true
+}
+
 func parse(p string) (datetime, error) {
-	// As later input string will be split using space(s)
-	// ensure there IS at least one space around the operator
-	// So accept: 3+4 or 3 + 4
-	for _, c := range []string{"+", "-", "*", "/"} {
-		p = strings.ReplaceAll(p, c, " "+c+" ")
+	return evaluate(p, nil)
+}
+
+func evaluate(p string, env environment) (datetime, error) {
+	if name, expr, ok := parseAssignment(p); ok {
+		if reservedNames[name] {
+			result := datetime{
+				parameter: p,
+			}
+			return result, fmt.Errorf(tr("%s is a reserved name"), name)
+		}
+		p = expr
 	}
 
-	fields := strings.Fields(p)
+	// before conversions, `round 90m to h` isn't `round 90m` as `h`
+	if match := roundRegex.FindStringSubmatch(strings.TrimSpace(p)); match != nil {
+		return evaluateRound(match, env)
+	}
+
+	if expr, target, ok := parseConversion(p); ok {
+		return evaluateConversion(expr, target, env)
+	}
+
+	fields := tokenize(businessDates(workTimeRegex.ReplaceAllString(p, "${1}w${2}")))
 
 	switch len(fields) {
 	case 0:
 		result := datetime{
 			parameter: p,
 		}
-		return result, errors.New("nothing to calculate")
-	case 1:
+		return result, errors.New(tr("nothing to calculate"))
+	case 2:
 		result := datetime{
-			kind:      none,
 			parameter: p,
 		}
+		return result, errors.New(tr("missing operator"))
+	}
 
-		if err := parseField(fields[0], &result); err == nil {
-			return result, nil
-		}
-	case 2:
+	if len(fields)%2 == 0 {
 		result := datetime{
 			parameter: p,
 		}
-		return result, errors.New("missing operator")
-	case 3:
-		dt1 := datetime{
-			parameter: p,
-		}
+		return result, errors.New(tr("input formatted incorrectly"))
+	}
 
-		if err := parseField(fields[0], &dt1); err != nil {
-			return dt1, nil
+	// <field> (<op> <field>)*
+	var operands []datetime
+	var operators []string
+	var texts []string // of operands, for errors
+
+	for i, f := range fields {
+		if i%2 == 1 {
+			if !strings.Contains("+-*/", f) || len(f) != 1 {
+				result := datetime{
+					parameter: p,
+				}
+				return result, errors.New(tr("allowed format: <field> <op> <field> where op = +-*/"))
+			}
+			operators = append(operators, f)
+			continue
 		}
 
-		dt2 := datetime{
+		dt := datetime{
+			kind:      none,
 			parameter: p,
 		}
 
-		if err := parseField(fields[2], &dt2); err != nil {
-			return dt2, nil
+		if err := parseOperand(f, env, &dt); err != nil {
+			return dt, err
 		}
+		operands = append(operands, dt)
+		texts = append(texts, f)
+	}
 
-		result := datetime{
-			parameter: p,
-		}
+	// `*` and `/` first, then `+` and `-`, left to right
+	for _, group := range []string{"*/", "+-"} {
+		for i := 0; i < len(operators); {
+			if !strings.Contains(group, operators[i]) {
+				i++
+				continue
+			}
 
-		if fields[1] == "+" {
-			result.calculateDT(dt1, dt2, add)
-		} else if fields[1] == "-" {
-			result.calculateDT(dt1, dt2, sub)
-		} else if fields[1] == "*" {
-			result.calculateDT(dt1, dt2, mul)
-		} else if fields[1] == "/" {
-			result.calculateDT(dt1, dt2, div)
-		} else {
-			return result, errors.New("allowed format: <field> <op> <field> where op = +-")
-		}
+			result := datetime{
+				parameter: p,
+			}
+			if err := result.calculateDT(operands[i], operands[i+1], operations[operators[i]]); err != nil {
+				return result, err
+			} else if result.kind == none {
+				return result, fmt.Errorf(tr("cannot calculate %s %s %s"), texts[i], operators[i], texts[i+1])
+			}
 
-		return result, nil
+			operands = append(operands[:i], append([]datetime{result}, operands[i+2:]...)...)
+			texts = append(texts[:i], append([]string{texts[i] + " " + operators[i] + " " + texts[i+1]}, texts[i+2:]...)...)
+			operators = append(operators[:i], operators[i+1:]...)
+		}
 	}
 
-	result := datetime{
-		parameter: p,
-	}
-	return result, errors.New("input formatted incorrectly")
+	return operands[0], nil
 }
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"strings"
 	"testing"
 	"time"
 )
@@ -360,6 +361,47 @@ func TestParse(t *testing.T) {
 				seconds: 2.0,
 			},
 		},
+		{
+			// a division, although 10/02 is a valid date
+			input: "10/2",
+			expected: datetime{
+				kind:    number,
+				ts:      0*24*3600 + 0*3600 + 0*60 + 5,
+				day:     0,
+				month:   0,
+				year:    0,
+				hour:    0,
+				minute:  0,
+				second:  5,
+				days:    5.0 / 3600.0 / 24.0,
+				hours:   5.0 / 3600.0,
+				minutes: 5.0 / 60.0,
+				seconds: 5.0,
+			},
+		},
+		{
+			input: "10/2/2025",
+			expected: datetime{
+				kind: timestamp,
+			},
+		},
+		{
+			input: "60/15",
+			expected: datetime{
+				kind:    number,
+				ts:      0*24*3600 + 0*3600 + 0*60 + 4,
+				day:     0,
+				month:   0,
+				year:    0,
+				hour:    0,
+				minute:  0,
+				second:  4,
+				days:    4.0 / 3600.0 / 24.0,
+				hours:   4.0 / 3600.0,
+				minutes: 4.0 / 60.0,
+				seconds: 4.0,
+			},
+		},
 		{
 			input: "1m/4",
 			expected: datetime{
@@ -398,3 +440,671 @@ func TestParse(t *testing.T) {
 	}
 
 }
+
+func TestEvaluate(t *testing.T) {
+	env := environment{}
+	standup := datetime{}
+	parseField("15m", &standup)
+	env["standup"] = standup
+
+	tests := []struct {
+		input string
+		kind  int
+		ts    int64
+		err   bool
+	}{
+		{input: "standup * 5", kind: duration, ts: 75 * 60},
+		{input: "2 * standup", kind: duration, ts: 30 * 60},
+		{input: "1h + 2 * 30m", kind: duration, ts: 2 * 3600},
+		{input: "1h - 10m - 5m", kind: duration, ts: 45 * 60},
+		{input: "meeting = standup + 45m", kind: duration, ts: 3600},
+		{input: "unknown + 1m", err: true},
+		{input: "now = 5", err: true},
+		{input: "1h +", err: true},
+		{input: "1h * 1h", err: true},
+		{input: "now * 2", err: true},
+		{input: "2 * 1500ms", kind: duration, ts: 3},
+		{input: "10/2 + 3", kind: number, ts: 8},
+		{input: "2 * 3 + 10/2", kind: number, ts: 11},
+		{input: "10/2 - 3", kind: number, ts: 2},
+		{input: "10 + 2", kind: number | duration, ts: 12},
+	}
+
+	for _, ts := range tests {
+		result, err := evaluate(ts.input, env)
+
+		if ts.err {
+			if err == nil {
+				t.Errorf(">>> Expected error for input: >%s<, got %+v\n", ts.input, result)
+			}
+			continue
+		}
+
+		if err != nil || result.kind != ts.kind || result.ts != ts.ts {
+			t.Error(">>> Input", ts.input)
+			t.Errorf(">>> Expected kind %d ts %d\n", ts.kind, ts.ts)
+			t.Errorf(">>> Result   kind %d ts %d err %v\n", result.kind, result.ts, err)
+		}
+	}
+
+	// operands as written, also when already calculated
+	errors := []struct {
+		input    string
+		expected string
+	}{
+		{input: "10/2 * now", expected: "cannot calculate 10 / 2 * now"},
+		{input: "1h + 2 * now", expected: "cannot calculate 2 * now"},
+		{input: "1h * 1h", expected: "cannot calculate 1h * 1h"},
+	}
+
+	for _, ts := range errors {
+		if _, err := evaluate(ts.input, env); err == nil || err.Error() != ts.expected {
+			t.Error(">>> Input", ts.input)
+			t.Errorf(">>> Expected %s\n", ts.expected)
+			t.Errorf(">>> Result   %v\n", err)
+		}
+	}
+}
+
+func TestParseDate(t *testing.T) {
+	// Wednesday
+	timeNow = func() time.Time { return time.Date(2024, 11, 20, 10, 0, 0, 0, time.Local) }
+	defer func() { timeNow = time.Now }()
+
+	tests := []struct {
+		input      string
+		dateFormat string
+		expected   time.Time
+		ok         bool
+	}{
+		{input: "22/11", expected: time.Date(2024, 11, 22, 0, 0, 0, 0, time.Local), ok: true},
+		{input: "22/11/25", expected: time.Date(2025, 11, 22, 0, 0, 0, 0, time.Local), ok: true},
+		{input: "22/11/2023 18:30", expected: time.Date(2023, 11, 22, 18, 30, 0, 0, time.Local), ok: true},
+		{input: "11/22", dateFormat: "3", expected: time.Date(2024, 11, 22, 0, 0, 0, 0, time.Local), ok: true},
+		{input: "2024-02-29", expected: time.Date(2024, 2, 29, 0, 0, 0, 0, time.Local), ok: true},
+		{input: "friday", expected: time.Date(2024, 11, 22, 0, 0, 0, 0, time.Local), ok: true},
+		{input: "Wed 09:00", expected: time.Date(2024, 11, 27, 9, 0, 0, 0, time.Local), ok: true},
+		{input: "31/02"},
+		{input: "22/13"},
+		{input: "22/11 25:00"},
+		{input: "fries"},
+	}
+
+	for _, ts := range tests {
+		t.Setenv("DATE_FORMAT", ts.dateFormat)
+
+		result, ok := parseDate(ts.input)
+		if ok != ts.ok || !result.Equal(ts.expected) {
+			t.Error(">>> Input", ts.input)
+			t.Errorf(">>> Expected %v %v\n", ts.expected, ts.ok)
+			t.Errorf(">>> Result   %v %v\n", result, ok)
+		}
+	}
+
+	result, err := evaluate("24/12/2024 - 22/11/2024 18:00", nil)
+	if err != nil || result.kind != duration || result.ts != 31*24*3600+6*3600 {
+		t.Errorf(">>> Expected 31d6h, got %+v (%v)\n", result, err)
+	}
+
+	result, err = evaluate("now - 1d", nil)
+	if err != nil || result.kind != timestamp || !result.dt.Equal(timeNow().Add(-24*time.Hour)) {
+		t.Errorf(">>> Expected yesterday, got %+v (%v)\n", result, err)
+	}
+}
+
+func TestTwelveHourClock(t *testing.T) {
+	timeNow = func() time.Time { return time.Date(2024, 11, 20, 10, 0, 0, 0, time.Local) }
+	defer func() { timeNow = time.Now }()
+
+	tests := []struct {
+		input    string
+		expected string
+		ok       bool
+	}{
+		{input: "9:30am + 8h45m", expected: "6:15 PM", ok: true},
+		{input: "9:30 PM + 30s", expected: "9:30:30 PM", ok: true},
+		{input: "12am", expected: "12:00 AM", ok: true},
+		{input: "12p.m.", expected: "12:00 PM", ok: true},
+		{input: "22/11/2024 9pm", expected: "9:00 PM", ok: true},
+		{input: "13pm"},
+		{input: "0am"},
+	}
+
+	t.Setenv("CLOCK_FORMAT", "12")
+	for _, ts := range tests {
+		dt, err := evaluate(ts.input, nil)
+		dt.format = "time"
+
+		if (err == nil) != ts.ok || (ts.ok && formatResult(dt) != ts.expected) {
+			t.Error(">>> Input", ts.input)
+			t.Errorf(">>> Expected %s %v\n", ts.expected, ts.ok)
+			t.Errorf(">>> Result   %+v %v\n", dt, err)
+		}
+	}
+
+	t.Setenv("CLOCK_FORMAT", "")
+	dt, err := evaluate("9:30am + 8h45m as time", nil)
+	if err != nil || formatResult(dt) != "18:15:00" {
+		t.Errorf(">>> Expected 18:15:00, got %+v (%v)\n", dt, err)
+	}
+}
+
+func TestTimeOfDay(t *testing.T) {
+	timeNow = func() time.Time { return time.Date(2024, 11, 20, 10, 0, 0, 0, time.Local) }
+	defer func() { timeNow = time.Now }()
+
+	tests := []struct {
+		input    string
+		kind     int
+		expected string
+	}{
+		{input: "@09:00 + 10h30m", kind: timeOfDay, expected: "19:30:00"},
+		{input: "@23:00 + 2h", kind: timeOfDay, expected: "01:00:00 (+1 day)"},
+		{input: "30m + 11pm", kind: timeOfDay, expected: "23:30:00"},
+		{input: "@01:00 - 3h", kind: timeOfDay, expected: "22:00:00 (-1 day)"},
+		{input: "@23:00 + 50h", kind: timeOfDay, expected: "01:00:00 (+3 days)"},
+		{input: "@17:30 - @09:00", kind: duration, expected: "0 days, 8 hours, 30 minutes and 0 seconds"},
+		{input: "@23:00 + 2h in full", kind: timeOfDay, expected: "Thursday, 21 November 2024 01:00:00"},
+		{input: "9am in 12h", kind: timeOfDay, expected: "9:00:00 AM"},
+		{input: "23:00 + 2h", kind: duration, expected: "0 days, 2 hours, 23 minutes and 0 seconds"},
+	}
+
+	for _, ts := range tests {
+		dt, err := evaluate(ts.input, nil)
+
+		if err != nil || dt.kind != ts.kind || formatResult(dt) != ts.expected {
+			t.Error(">>> Input", ts.input)
+			t.Errorf(">>> Expected %s\n", ts.expected)
+			t.Errorf(">>> Result   %s %+v %v\n", formatResult(dt), dt, err)
+		}
+	}
+
+	for _, input := range []string{"@09:00 + @10:00", "@09:00 * 2", "2h - @09:00"} {
+		if _, err := evaluate(input, nil); err == nil || !strings.Contains(err.Error(), "time of day") {
+			t.Errorf(">>> Expected time of day error for %s, got %v\n", input, err)
+		}
+	}
+}
+
+func TestClockRange(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int64
+	}{
+		{input: "22:00..06:30", expected: 8*3600 + 30*60},
+		{input: "22:00..06:30 - 30m", expected: 8 * 3600},
+		{input: "from 22:00 to 06:30", expected: 8*3600 + 30*60},
+		{input: "09:00 to 17:30", expected: 8*3600 + 30*60},
+		{input: "9am .. 5:30 pm - 45m", expected: 7*3600 + 45*60},
+		{input: "2 * 22:00..06:30", expected: 17 * 3600},
+		{input: "08:00..08:00", expected: 0},
+	}
+
+	for _, ts := range tests {
+		dt, err := evaluate(ts.input, nil)
+
+		if err != nil || dt.kind != duration || dt.ts != ts.expected {
+			t.Error(">>> Input", ts.input)
+			t.Errorf(">>> Expected %d\n", ts.expected)
+			t.Errorf(">>> Result   %+v %v\n", dt, err)
+		}
+	}
+
+	if _, err := evaluate("22:00..25:00", nil); err == nil {
+		t.Errorf(">>> Expected error for 25:00\n")
+	}
+}
+
+func TestEpochs(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected time.Time
+		note     string
+	}{
+		{input: "1709420400u", expected: time.Unix(1709420400, 0)},
+		{input: "1709420400123u", expected: time.UnixMilli(1709420400123), note: "13 digits, read as milliseconds"},
+		{input: "1709420400123456u", expected: time.UnixMicro(1709420400123456), note: "16 digits, read as microseconds"},
+		{input: "1709420400123456789u", expected: time.Unix(0, 1709420400123456789), note: "19 digits, read as nanoseconds"},
+		{input: "1709420400ums", expected: time.UnixMilli(1709420400)},
+		{input: "1709420400uus", expected: time.UnixMicro(1709420400)},
+		{input: "1709420400uns", expected: time.Unix(0, 1709420400)},
+	}
+
+	for _, ts := range tests {
+		var dt datetime
+		err := parseField(ts.input, &dt)
+
+		if err != nil || dt.kind != timestamp || !dt.dt.Equal(ts.expected) || dt.note != ts.note {
+			t.Error(">>> Input", ts.input)
+			t.Errorf(">>> Expected %v (%s)\n", ts.expected, ts.note)
+			t.Errorf(">>> Result   %+v %v\n", dt, err)
+		}
+	}
+
+	for _, input := range []string{"1709420400123456ums", "99999999999999999999u"} {
+		if err := parseField(input, &datetime{}); err == nil {
+			t.Errorf(">>> Expected error for out of range epoch %s\n", input)
+		}
+	}
+
+	dt, err := evaluate("1709420400123ums as uus", nil)
+	if err != nil || formatResult(dt) != "1709420400123000" {
+		t.Errorf(">>> Expected 1709420400123000, got %+v (%v)\n", dt, err)
+	}
+
+	dt, _ = evaluate("1709420400123u in iso", nil)
+	items := resultItems(dt)
+	if len(items) < 2 || items[0].Title != "ISO 8601" || strings.Contains(items[0].Subtitle, "read as") ||
+		items[1].Title != "Result" || !strings.HasSuffix(items[1].Subtitle, "(13 digits, read as milliseconds)") {
+		t.Errorf(">>> Expected the note on the result, got %+v\n", items)
+	}
+}
+
+func TestEpochSystems(t *testing.T) {
+	t.Setenv("DATE_FORMAT", "")
+
+	tests := []struct {
+		input    string
+		format   string
+		expected string
+	}{
+		{input: "133538940000000000ft", format: "iso", expected: "2024-03-02T23:00:00Z"},
+		{input: "638450172000000000ticks", format: "iso", expected: "2024-03-02T23:00:00Z"},
+		{input: "731113200cocoa", format: "iso", expected: "2024-03-02T23:00:00Z"},
+		{input: "1393455618gps", format: "iso", expected: "2024-03-02T23:00:00Z"},
+		{input: "13353894000000000webkit", format: "iso", expected: "2024-03-02T23:00:00Z"},
+		{input: "3792265200hfs", format: "iso", expected: "2024-03-02T23:00:00Z"},
+		{input: "45353.5xl", format: "time", expected: "12:00:00"},
+		{input: "1xl", format: "date", expected: "1900-01-01"},
+		{input: "1709420400u", format: "filetime", expected: "133538940000000000"},
+		{input: "1709420400u", format: "ticks", expected: "638450172000000000"},
+		{input: "1709420400u", format: "cocoa", expected: "731113200"},
+		{input: "1709420400u", format: "gps", expected: "1393455618"},
+		{input: "1709420400u", format: "webkit", expected: "13353894000000000"},
+		{input: "1709420400u", format: "hfs", expected: "3792265200"},
+		{input: "02/03/2024 12:00", format: "excel", expected: "45353.5"},
+		{input: "28/02/1900", format: "excel", expected: "59"},
+		{input: "01/03/1900", format: "excel", expected: "61"},
+	}
+
+	for _, ts := range tests {
+		dt, err := evaluate(ts.input, nil)
+		if ts.format == "iso" {
+			dt.dt = dt.dt.UTC()
+		}
+		dt.format = ts.format
+
+		if err != nil || dt.kind != timestamp || formatResult(dt) != ts.expected {
+			t.Error(">>> Input", ts.input, ts.format)
+			t.Errorf(">>> Expected %s\n", ts.expected)
+			t.Errorf(">>> Result   %s %+v %v\n", formatResult(dt), dt, err)
+		}
+	}
+
+	// ISO 8601 and Unix timestamp always, the epoch read when it's another one
+	shown := []struct {
+		input string
+		title string
+	}{
+		{input: "133538940000000000ft", title: "ISO 8601, Unix timestamp, Windows FILETIME"},
+		{input: "45353.5xl", title: "ISO 8601, Unix timestamp, Excel serial"},
+		{input: "1709420400u", title: "ISO 8601, Unix timestamp"},
+		{input: "1709420400123u", title: "ISO 8601, Unix timestamp, Unix timestamp (ms)"},
+		{input: "1709420400uns", title: "ISO 8601, Unix timestamp, Unix timestamp (ns)"},
+		{input: "02/03/2024", title: "ISO 8601, Unix timestamp"},
+	}
+
+	for _, ts := range shown {
+		dt, _ := evaluate(ts.input, nil)
+		titles := []string{}
+		for _, item := range resultItems(dt)[1:] {
+			titles = append(titles, item.Title)
+		}
+
+		if strings.Join(titles, ", ") != ts.title {
+			t.Error(">>> Input", ts.input)
+			t.Errorf(">>> Expected Result, %s\n", ts.title)
+			t.Errorf(">>> Result   %v\n", titles)
+		}
+	}
+}
+
+func TestIDs(t *testing.T) {
+	tests := []struct {
+		input    string
+		epoch    string
+		expected time.Time
+		note     string
+	}{
+		{input: "c232ab00-9414-11ec-b3c8-9f6bdeced846", expected: time.Date(2022, 2, 22, 19, 22, 22, 0, time.UTC), note: "UUID v1"},
+		{input: "1EC9414C-232A-6B00-B3C8-9F6BDECED846", expected: time.Date(2022, 2, 22, 19, 22, 22, 0, time.UTC), note: "UUID v6"},
+		{input: "017f22e2-79b0-7cc3-98c4-dc0c0c07398f", expected: time.UnixMilli(0x017f22e279b0), note: "UUID v7"},
+		{input: "01ARZ3NDEKTSV4RRFFQ69G5FAV", expected: time.UnixMilli(1469922850259), note: "ULID"},
+		{input: "0ujtsYcgvSTl8PAuAdqWYSMnLOv", expected: time.Date(2017, 10, 10, 4, 0, 47, 0, time.UTC), note: "KSUID"},
+		{input: "507f1f77bcf86cd799439011", expected: time.Unix(0x507f1f77, 0), note: "ObjectId"},
+		{input: "1541815603606036480sf", expected: time.UnixMilli(1656432460105), note: "Snowflake"},
+		{input: "175928847299117063sf", epoch: "discord", expected: time.UnixMilli(1462015105796), note: "Snowflake"},
+	}
+
+	for _, ts := range tests {
+		t.Setenv("SNOWFLAKE_EPOCH", ts.epoch)
+
+		var dt datetime
+		err := parseField(ts.input, &dt)
+
+		if err != nil || dt.kind != timestamp || !dt.dt.Equal(ts.expected) || dt.note != ts.note {
+			t.Error(">>> Input", ts.input)
+			t.Errorf(">>> Expected %v (%s)\n", ts.expected, ts.note)
+			t.Errorf(">>> Result   %+v %v\n", dt, err)
+		}
+	}
+
+	dt, err := evaluate("c232ab00-9414-11ec-b3c8-9f6bdeced846 - 1645557742u", nil)
+	if err != nil || dt.kind != duration || dt.ts != 0 {
+		t.Errorf(">>> Expected 0s, got %+v (%v)\n", dt, err)
+	}
+
+	if err := parseField("c232ab00-9414-41ec-b3c8-9f6bdeced846", &datetime{}); err == nil {
+		t.Errorf(">>> Expected error for UUID v4\n")
+	}
+
+	// ObjectId of 2088, KSUID of 4560, ULID of 10889 and ObjectId of 1970
+	for _, input := range []string{"deadbeefdeadbeefdeadbeef", "2ABCDEFGHJKMNPQRSTVWXYZ012", "7ZZZZZZZZZZZZZZZZZZZZZZZZZ", "00000000aaaaaaaaaaaaaaaa"} {
+		if err := parseField(input, &datetime{}); err == nil {
+			t.Errorf(">>> Expected error for %s\n", input)
+		}
+	}
+
+	if _, err := evaluate("deadbeefdeadbeefdeadbeef", nil); err == nil || !strings.Contains(err.Error(), "unknown variable") {
+		t.Errorf(">>> Expected unknown variable, got %v\n", err)
+	}
+}
+
+func TestJWT(t *testing.T) {
+	timeNow = func() time.Time { return time.Unix(1700000000, 0) }
+	defer func() { timeNow = time.Now }()
+
+	// {"alg":"HS256","typ":"JWT"}.{"sub":"1","iat":1699989200,"nbf":1699989200,"exp":1700000720}
+	token := "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9.eyJzdWIiOiIxIiwiaWF0IjoxNjk5OTg5MjAwLCJuYmYiOjE2OTk5ODkyMDAsImV4cCI6MTcwMDAwMDcyMH0.c2ln-_"
+
+	dt, err := evaluate(token, nil)
+	if err != nil || dt.kind != jwt|timestamp || !dt.dt.Equal(time.Unix(1700000720, 0)) {
+		t.Fatalf(">>> Expected JWT expiring at 1700000720, got %+v (%v)\n", dt, err)
+	}
+
+	expected := map[string]string{
+		"Result":   "expires in 12m",
+		"Lifetime": "3h 12m",
+	}
+	for _, item := range resultItems(dt) {
+		if e, ok := expected[item.Title]; ok && item.Subtitle != e {
+			t.Errorf(">>> %s: expected %s, got %s\n", item.Title, e, item.Subtitle)
+		}
+	}
+
+	dt, err = evaluate(token+" - now", nil)
+	if err != nil || dt.kind != duration || dt.ts != 720 {
+		t.Errorf(">>> Expected 12m, got %+v (%v)\n", dt, err)
+	}
+
+	dt, err = evaluate(token+" as iat", nil)
+	if err != nil || formatResult(dt) != formatDate(time.Unix(1699989200, 0))+" "+formatClock(time.Unix(1699989200, 0))+" (issued 3h ago)" {
+		t.Errorf(">>> Expected issued 3h ago, got %s (%v)\n", formatResult(dt), err)
+	}
+
+	timeNow = func() time.Time { return time.Unix(1700000720+3*3600, 0) }
+	if result := formatExpiry(dt.dt); result != "expired 3h ago" {
+		t.Errorf(">>> Expected expired 3h ago, got %s\n", result)
+	}
+
+	if err := parseField("a.b.c", &datetime{}); err == nil {
+		t.Errorf(">>> Expected error for a.b.c\n")
+	}
+}
+
+func TestDurationDialects(t *testing.T) {
+	tests := []struct {
+		input      string
+		expected   time.Duration
+		golang     string
+		prometheus string
+	}{
+		{input: "1h2m3.5s", expected: time.Hour + 2*time.Minute + 3500*time.Millisecond, golang: "1h2m3.5s", prometheus: "1h2m3s500ms"},
+		{input: "350ms", expected: 350 * time.Millisecond, golang: "350ms", prometheus: "350ms"},
+		{input: "1w2d", expected: 9 * 24 * time.Hour, golang: "216h0m0s", prometheus: "1w2d"},
+		{input: "5m30s", expected: 5*time.Minute + 30*time.Second, golang: "5m30s", prometheus: "5m30s"},
+		{input: "1min 30s", expected: 90 * time.Second, golang: "1m30s", prometheus: "1m30s"},
+		{input: "2 weeks", expected: 14 * 24 * time.Hour, golang: "336h0m0s", prometheus: "2w"},
+		{input: "1 hour 30 mins + 10 secs", expected: 90*time.Minute + 10*time.Second, golang: "1h30m10s", prometheus: "1h30m10s"},
+		{input: "1.5h", expected: 90 * time.Minute, golang: "1h30m0s", prometheus: "1h30m"},
+		{input: "350ms * 4", expected: 1400 * time.Millisecond, golang: "1.4s", prometheus: "1s400ms"},
+		{input: "1s / 4", expected: 250 * time.Millisecond, golang: "250ms", prometheus: "250ms"},
+		{input: "1s - 1500ms", expected: -500 * time.Millisecond, golang: "-500ms", prometheus: "-500ms"},
+	}
+
+	for _, ts := range tests {
+		dt, err := evaluate(ts.input, nil)
+
+		goFormat, prometheusFormat := dt, dt
+		goFormat.format, prometheusFormat.format = "go", "prometheus"
+
+		if err != nil || dt.kind != duration || dt.nanoseconds() != ts.expected ||
+			formatResult(goFormat) != ts.golang || formatResult(prometheusFormat) != ts.prometheus {
+			t.Error(">>> Input", ts.input)
+			t.Errorf(">>> Expected %v %s %s\n", ts.expected, ts.golang, ts.prometheus)
+			t.Errorf(">>> Result   %v %s %s %v\n", dt.nanoseconds(), formatResult(goFormat), formatResult(prometheusFormat), err)
+		}
+	}
+
+	dt, err := evaluate("1s / 250ms", nil)
+	if err != nil || dt.kind != number || dt.ts != 4 {
+		t.Errorf(">>> Expected 4, got %+v (%v)\n", dt, err)
+	}
+
+	errors := []struct {
+		input    string
+		expected string
+	}{
+		{input: "1h / 0", expected: "division by zero"},
+		{input: "5 / 0", expected: "division by zero"},
+		{input: "1s / 0ms", expected: "division by zero"},
+		{input: "1h / now", expected: "cannot calculate 1h / now"},
+		{input: "1h 2h", expected: "missing operator"},
+		{input: "30s 1m", expected: "missing operator"},
+	}
+
+	for _, ts := range errors {
+		if _, err := evaluate(ts.input, nil); err == nil || err.Error() != ts.expected {
+			t.Error(">>> Input", ts.input)
+			t.Errorf(">>> Expected %s\n", ts.expected)
+			t.Errorf(">>> Result   %v\n", err)
+		}
+	}
+
+	shown := map[string]string{}
+	for _, item := range resultItems(newDuration(90)) {
+		shown[item.Title] = item.Subtitle
+	}
+	if shown["Go duration"] != "1m30s" || shown["Prometheus"] != "1m30s" {
+		t.Errorf(">>> Expected Go and Prometheus durations, got %v\n", shown)
+	}
+}
+
+func TestTimecode(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+		frames   int64
+	}{
+		{input: "01:02:03:12 + 00:00:10:20 @25fps", expected: "01:02:14:07", frames: 93357},
+		{input: "01:02:03:12@25 + 10s", expected: "01:02:13:12", frames: 93337},
+		{input: "01:00:00;00", expected: "01:00:00;00", frames: 107892},
+		{input: "00:00:59;29 + 00:00:00;01", expected: "00:01:00;02", frames: 1800},
+		{input: "00:09:59;29 + 00:00:00;01", expected: "00:10:00;00", frames: 17982},
+		{input: "00:00:10:00 @24fps * 3", expected: "00:00:30:00", frames: 720},
+		{input: "00:00:00:00 - 00:00:01:00", expected: "-00:00:01:00", frames: -25},
+	}
+
+	t.Setenv("FRAME_RATE", "")
+	for _, ts := range tests {
+		dt, err := evaluate(ts.input, nil)
+
+		if err != nil || dt.kind != timecode || formatTimecode(dt) != ts.expected || dt.ts != ts.frames {
+			t.Error(">>> Input", ts.input)
+			t.Errorf(">>> Expected %s (%d frames)\n", ts.expected, ts.frames)
+			t.Errorf(">>> Result   %s %+v %v\n", formatTimecode(dt), dt, err)
+		}
+	}
+
+	for _, input := range []string{"00:01:00;00", "00:00:00:25", "00:00:00:01@25 + 00:00:00:01@30",
+		"00:00:01:00 @25fps + 00:00:01:00 @30fps", "00:00:01:00 / 0", "1h - 00:00:01:00", "00:00:01:00 * 1h"} {
+		if _, err := evaluate(input, nil); err == nil {
+			t.Errorf(">>> Expected error for %s\n", input)
+		}
+	}
+
+	dt, err := evaluate("00:00:01:00 @30fps + 00:00:01:00 @30fps", nil)
+	if err != nil || dt.ts != 60 || dt.fps != 30 {
+		t.Errorf(">>> Expected 60 frames at 30 fps, got %+v (%v)\n", dt, err)
+	}
+
+	dt, _ = evaluate("00:00:00:00 - 00:01:30:00 as hh:mm:ss", nil)
+	if formatResult(dt) != "-00:01:30" {
+		t.Errorf(">>> Expected -00:01:30, got %s\n", formatResult(dt))
+	}
+}
+
+func TestCalendarSpan(t *testing.T) {
+	tests := []struct {
+		from, to                 time.Time
+		years, months, days, sec int64
+	}{
+		{
+			from:  time.Date(1988, 6, 15, 0, 0, 0, 0, time.UTC),
+			to:    time.Date(2024, 11, 22, 0, 0, 1, 0, time.UTC),
+			years: 36, months: 5, days: 7, sec: 1,
+		},
+		{
+			from:  time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC),
+			to:    time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+			years: 0, months: 1, days: 1,
+		},
+		{
+			from:  time.Date(2023, 12, 20, 0, 0, 0, 0, time.UTC),
+			to:    time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC),
+			years: 0, months: 0, days: 21,
+		},
+		{
+			from:  time.Date(2024, 11, 22, 0, 0, 0, 0, time.UTC),
+			to:    time.Date(2023, 10, 21, 0, 0, 0, 0, time.UTC),
+			years: -1, months: -1, days: -1,
+		},
+	}
+
+	for _, ts := range tests {
+		result := calendarSpan(ts.from, ts.to)
+
+		if result.year != ts.years || result.month != ts.months || result.day != ts.days || result.second != ts.sec ||
+			result.ts != int64(ts.to.Sub(ts.from).Seconds()) {
+			t.Errorf(">>> Span %v - %v\n", ts.from, ts.to)
+			t.Errorf(">>> Result %+v\n", result)
+		}
+	}
+}
+
+func TestBilling(t *testing.T) {
+	tests := []struct {
+		input string
+		kind  int
+		ts    int64
+	}{
+		{input: "7h45m * 120/h", kind: money, ts: 93000},
+		{input: "120/h * 7h45m", kind: money, ts: 93000},
+		{input: "1h30m * 2/m", kind: money, ts: 18000},
+		{input: "10m * 99.99/h", kind: money, ts: 1667},
+		{input: "7h45m * 120/h + 1h * 120/h", kind: money, ts: 105000},
+		{input: "2 * 120/h", kind: rate, ts: 24000},
+		{input: "8h * 800/d", kind: money, ts: 80000},
+		{input: "1h * 800/d", kind: money, ts: 10000},
+		{input: "1h * 200/h / 3", kind: money, ts: 6667},
+		{input: "round 1h - 8h50m to 6m", kind: duration, ts: -(7*3600 + 48*60)},
+		{input: "round up 1h - 8h31s to 15m", kind: duration, ts: -(7*3600 + 15*60)},
+		{input: "round down 1h - 8h59m to 1h", kind: duration, ts: -7 * 3600},
+		{input: "round 7h50m to 6m", kind: duration, ts: 7*3600 + 48*60},
+		{input: "round 7h51m to 6m", kind: duration, ts: 7*3600 + 54*60},
+		{input: "round up 7h31s to 15m", kind: duration, ts: 7*3600 + 15*60},
+		{input: "round up 7h to 15m", kind: duration, ts: 7 * 3600},
+		{input: "round down 7h59m to 1h", kind: duration, ts: 7 * 3600},
+		{input: "round up 7h38m to 15m * 100/h", kind: money, ts: 77500},
+		{input: "round 90m to h", kind: duration, ts: 2 * 3600},
+		{input: "round down 90m to h", kind: duration, ts: 3600},
+		{input: "round 100m to 1h in minutes", kind: duration, ts: 2 * 3600},
+	}
+
+	for _, ts := range tests {
+		result, err := evaluate(ts.input, nil)
+		if err != nil || result.kind != ts.kind || result.ts != ts.ts {
+			t.Error(">>> Input", ts.input)
+			t.Errorf(">>> Expected kind %d ts %d\n", ts.kind, ts.ts)
+			t.Errorf(">>> Result   kind %d ts %d err %v\n", result.kind, result.ts, err)
+		}
+	}
+
+	t.Setenv("WORK_HOURS", "09:00-16:30")
+	if result, err := evaluate("1h * 800/d", nil); err != nil || result.ts != 10667 {
+		t.Errorf(">>> Expected 106.67 for 1h of a 7h30m day, got %d (%v)\n", result.ts, err)
+	}
+	if dt, _ := evaluate("800/d", nil); formatResult(dt) != "800.00/d" {
+		t.Errorf(">>> Unexpected %s\n", formatResult(dt))
+	}
+
+	for _, input := range []string{"1h + 120/h", "120/h + 2/m", "930.00 / 0", "now * 120/h"} {
+		if result, err := evaluate(input, nil); err == nil {
+			t.Errorf(">>> Expected error for input: >%s<, got %+v\n", input, result)
+		}
+	}
+
+	t.Setenv("CURRENCY", "EUR")
+	if s := formatMoney(-93050); s != "-930.50 EUR" {
+		t.Errorf(">>> Unexpected %s\n", s)
+	}
+	t.Setenv("CURRENCY", "$")
+	if s := formatMoney(-93050); s != "-$930.50" {
+		t.Errorf(">>> Unexpected %s\n", s)
+	}
+}
+
+func TestConversion(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+		err      bool
+	}{
+		{input: "3d4h in minutes", expected: "4,560.00 minutes"},
+		{input: "90000s in hours", expected: "25.00 hours"},
+		{input: "76h as hh:mm", expected: "76:00"},
+		{input: "1d12h to days", expected: "1.50 days"},
+		{input: "1709420400u as unix", expected: "1709420400"},
+		{input: "1709420400u in UTC as iso", expected: "2024-03-02T23:00:00Z"},
+		{input: "1709420400u in Asia/Tokyo as iso", expected: "2024-03-03T08:00:00+09:00"},
+		{input: "round 7h50m to 6m in minutes", expected: "468.00 minutes"},
+		{input: "1h as iso", err: true},
+		{input: "1h in Europe/Warsaw", err: true},
+	}
+
+	for _, ts := range tests {
+		result, err := evaluate(ts.input, nil)
+		if ts.err != (err != nil) || (err == nil && formatResult(result) != ts.expected) {
+			t.Error(">>> Input", ts.input)
+			t.Errorf(">>> Expected %s\n", ts.expected)
+			t.Errorf(">>> Result   %s %v\n", formatResult(result), err)
+		}
+	}
+
+	// requested format goes first, hidden ones only when requested
+	result, _ := evaluate("1h as hh:mm", nil)
+	items := resultItems(result)
+	if items[0].Title != "Result (hh:mm)" || len(items) != len(outputItemFormatsDuration)-1 {
+		t.Errorf(">>> Unexpected items %+v\n", items)
+	}
+}
@@ -0,0 +1,442 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Number of occurrences listed when the rule has no COUNT
+const rruleOccurrences = 10
+
+// Upper limit of occurrences expanded, e.g. for `between` of a daily rule
+const rruleLimit = 5000
+
+// RFC 5545 weekday with optional ordinal, e.g. `-1FR`
+type rruleWeekday struct {
+	n       int // 0 for every such weekday
+	weekday time.Weekday
+}
+
+// RFC 5545 recurrence rule
+type rrule struct {
+	freq       string
+	interval   int
+	count      int
+	until      time.Time
+	byMonth    []int
+	byMonthDay []int
+	byDay      []rruleWeekday
+	bySetPos   []int
+	weekStart  time.Weekday
+}
+
+var rruleWeekdays = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+// `FREQ=MONTHLY;BYDAY=-1FR;COUNT=6`
+func parseRRule(s string) (rrule, error) {
+	r := rrule{interval: 1, weekStart: time.Monday}
+
+	s = strings.TrimPrefix(strings.ToUpper(s), "RRULE:")
+	for _, part := range strings.Split(s, ";") {
+		name, value, found := strings.Cut(part, "=")
+		if !found {
+			return r, fmt.Errorf(tr("invalid rule part %s"), part)
+		}
+
+		var err error
+		switch name {
+		case "FREQ":
+			switch value {
+			case "DAILY", "WEEKLY", "MONTHLY", "YEARLY":
+				r.freq = value
+			default:
+				return r, fmt.Errorf(tr("unsupported FREQ %s"), value)
+			}
+		case "INTERVAL":
+			r.interval, err = strconv.Atoi(value)
+			if err == nil && r.interval < 1 {
+				err = errors.New(tr("INTERVAL must be positive"))
+			}
+		case "COUNT":
+			r.count, err = strconv.Atoi(value)
+			if err == nil && r.count < 1 {
+				err = errors.New(tr("COUNT must be positive"))
+			}
+		case "UNTIL":
+			r.until, err = parseRRuleDate(value)
+		case "BYMONTH":
+			r.byMonth, err = parseRRuleInts(value, 1, 12)
+		case "BYMONTHDAY":
+			r.byMonthDay, err = parseRRuleInts(value, -31, 31)
+		case "BYSETPOS":
+			r.bySetPos, err = parseRRuleInts(value, -366, 366)
+		case "BYDAY":
+			r.byDay, err = parseRRuleWeekdays(value)
+		case "WKST":
+			wd, ok := rruleWeekdays[value]
+			if !ok {
+				err = fmt.Errorf(tr("invalid WKST %s"), value)
+			}
+			r.weekStart = wd
+		default:
+			err = fmt.Errorf(tr("unsupported rule part %s"), name)
+		}
+
+		if err != nil {
+			return r, err
+		}
+	}
+
+	if r.freq == "" {
+		return r, errors.New(tr("rule needs FREQ"))
+	}
+	return r, nil
+}
+
+// `20250101` or `20250101T120000Z`
+func parseRRuleDate(s string) (time.Time, error) {
+	for _, layout := range []string{"20060102T150405Z", "20060102T150405", "20060102"} {
+		loc := time.Local
+		if strings.HasSuffix(layout, "Z") {
+			loc = time.UTC
+		}
+		if t, err := time.ParseInLocation(layout, s, loc); err == nil {
+			if layout == "20060102" {
+				// whole day
+				t = t.AddDate(0, 0, 1).Add(-time.Second)
+			}
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf(tr("invalid UNTIL %s"), s)
+}
+
+func parseRRuleInts(s string, min, max int) ([]int, error) {
+	var values []int
+	for _, v := range strings.Split(s, ",") {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < min || n > max || n == 0 {
+			return nil, fmt.Errorf(tr("invalid value %s"), v)
+		}
+		values = append(values, n)
+	}
+	return values, nil
+}
+
+func parseRRuleWeekdays(s string) ([]rruleWeekday, error) {
+	var days []rruleWeekday
+	for _, v := range strings.Split(s, ",") {
+		if len(v) < 2 {
+			return nil, fmt.Errorf(tr("invalid BYDAY %s"), v)
+		}
+
+		wd, ok := rruleWeekdays[v[len(v)-2:]]
+		if !ok {
+			return nil, fmt.Errorf(tr("invalid BYDAY %s"), v)
+		}
+
+		n := 0
+		if ordinal := v[:len(v)-2]; ordinal != "" {
+			var err error
+			if n, err = strconv.Atoi(ordinal); err != nil || n == 0 || n < -53 || n > 53 {
+				return nil, fmt.Errorf(tr("invalid BYDAY %s"), v)
+			}
+		}
+		days = append(days, rruleWeekday{n, wd})
+	}
+	return days, nil
+}
+
+func containsInt(values []int, v int) bool {
+	for _, x := range values {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
+
+// Days from `from` to `to` (exclusive) matching BYDAY,
+// ordinals counted within the whole span, e.g. last Friday of a month
+func (r rrule) weekdaysIn(from, to time.Time) []time.Time {
+	var days []time.Time
+	for _, bd := range r.byDay {
+		var matching []time.Time
+		for t := from; t.Before(to); t = t.AddDate(0, 0, 1) {
+			if t.Weekday() == bd.weekday {
+				matching = append(matching, t)
+			}
+		}
+
+		if bd.n == 0 {
+			days = append(days, matching...)
+		} else if bd.n > 0 && bd.n <= len(matching) {
+			days = append(days, matching[bd.n-1])
+		} else if bd.n < 0 && -bd.n <= len(matching) {
+			days = append(days, matching[len(matching)+bd.n])
+		}
+	}
+	return days
+}
+
+// Days of the month matching BYMONTHDAY and BYDAY, or day of dtstart
+func (r rrule) daysInMonth(month time.Time, start time.Time) []time.Time {
+	next := month.AddDate(0, 1, 0)
+	last := next.AddDate(0, 0, -1).Day()
+
+	var days []time.Time
+	if len(r.byMonthDay) > 0 {
+		for _, d := range r.byMonthDay {
+			if d < 0 {
+				d = last + d + 1
+			}
+			if d >= 1 && d <= last {
+				days = append(days, month.AddDate(0, 0, d-1))
+			}
+		}
+		if len(r.byDay) > 0 {
+			var filtered []time.Time
+			for _, t := range days {
+				if r.matchesWeekday(t) {
+					filtered = append(filtered, t)
+				}
+			}
+			days = filtered
+		}
+	} else if len(r.byDay) > 0 {
+		days = r.weekdaysIn(month, next)
+	} else if start.Day() <= last {
+		days = append(days, month.AddDate(0, 0, start.Day()-1))
+	}
+	return days
+}
+
+func (r rrule) matchesWeekday(t time.Time) bool {
+	for _, bd := range r.byDay {
+		if bd.weekday == t.Weekday() {
+			return true
+		}
+	}
+	return false
+}
+
+// Candidate days of the period starting at `period`
+func (r rrule) candidates(period time.Time, start time.Time) []time.Time {
+	var days []time.Time
+
+	switch r.freq {
+	case "DAILY":
+		days = []time.Time{period}
+		if len(r.byMonthDay) > 0 && !containsInt(r.byMonthDay, period.Day()) &&
+			!containsInt(r.byMonthDay, period.Day()-period.AddDate(0, 1, -period.Day()).Day()-1) {
+			days = nil
+		}
+		if len(r.byDay) > 0 && !r.matchesWeekday(period) {
+			days = nil
+		}
+	case "WEEKLY":
+		for i := 0; i < 7; i++ {
+			t := period.AddDate(0, 0, i)
+			if (len(r.byDay) == 0 && t.Weekday() == start.Weekday()) || (len(r.byDay) > 0 && r.matchesWeekday(t)) {
+				days = append(days, t)
+			}
+		}
+	case "MONTHLY":
+		days = r.daysInMonth(period, start)
+	case "YEARLY":
+		if len(r.byMonth) == 0 && len(r.byMonthDay) == 0 && len(r.byDay) > 0 {
+			// e.g. 20th Monday of the year
+			days = r.weekdaysIn(period, period.AddDate(1, 0, 0))
+		} else {
+			// every month for BYMONTHDAY, month of dtstart without any BY* part
+			months := r.byMonth
+			if len(months) == 0 && len(r.byMonthDay) > 0 {
+				months = []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}
+			} else if len(months) == 0 {
+				months = []int{int(start.Month())}
+			}
+			for _, m := range months {
+				days = append(days, r.daysInMonth(period.AddDate(0, m-1, 0), start)...)
+			}
+		}
+	}
+
+	if len(r.byMonth) > 0 {
+		var filtered []time.Time
+		for _, t := range days {
+			if containsInt(r.byMonth, int(t.Month())) {
+				filtered = append(filtered, t)
+			}
+		}
+		days = filtered
+	}
+
+	sort.Slice(days, func(i, j int) bool { return days[i].Before(days[j]) })
+
+	if len(r.bySetPos) > 0 {
+		var selected []time.Time
+		for _, pos := range r.bySetPos {
+			if pos > 0 && pos <= len(days) {
+				selected = append(selected, days[pos-1])
+			} else if pos < 0 && -pos <= len(days) {
+				selected = append(selected, days[len(days)+pos])
+			}
+		}
+		sort.Slice(selected, func(i, j int) bool { return selected[i].Before(selected[j]) })
+		days = selected
+	}
+	return days
+}
+
+// First day of the period containing t
+func (r rrule) periodStart(t time.Time) time.Time {
+	t = midnight(t)
+	switch r.freq {
+	case "WEEKLY":
+		return t.AddDate(0, 0, -((int(t.Weekday()) - int(r.weekStart) + 7) % 7))
+	case "MONTHLY":
+		return t.AddDate(0, 0, 1-t.Day())
+	case "YEARLY":
+		return time.Date(t.Year(), time.January, 1, 0, 0, 0, 0, t.Location())
+	}
+	return t
+}
+
+func (r rrule) nextPeriod(period time.Time) time.Time {
+	switch r.freq {
+	case "WEEKLY":
+		return period.AddDate(0, 0, 7*r.interval)
+	case "MONTHLY":
+		return period.AddDate(0, r.interval, 0)
+	case "YEARLY":
+		return period.AddDate(r.interval, 0, 0)
+	}
+	return period.AddDate(0, 0, r.interval)
+}
+
+// Occurrences from dtstart, at most n of them and none after `end` if set
+func (r rrule) occurrences(start time.Time, end time.Time, n int) []time.Time {
+	var result []time.Time
+
+	clock := start.Sub(midnight(start))
+	// stop looking when there are no occurrences for that long
+	horizon := start.AddDate(100, 0, 0)
+
+	for period := r.periodStart(start); period.Before(horizon); period = r.nextPeriod(period) {
+		for _, day := range r.candidates(period, start) {
+			t := day.Add(clock)
+			if t.Before(start) {
+				continue
+			}
+			if (!r.until.IsZero() && t.After(r.until)) || (!end.IsZero() && t.After(end)) {
+				return result
+			}
+
+			result = append(result, t)
+			if len(result) == n || (r.count > 0 && len(result) == r.count) {
+				return result
+			}
+		}
+	}
+	return result
+}
+
+// `rrule <rule> [from <date>]`, `rrule <rule> [from <date>] between <date> and <date>`
+func rruleItems(match []string) Items {
+	r, err := parseRRule(match[1])
+	if err != nil {
+		return getItems(datetime{}, err)
+	}
+
+	var dates [3]time.Time
+	dates[0] = midnight(timeNow())
+	for i, p := range match[2:] {
+		if p == "" {
+			continue
+		}
+		dt, err := evaluateDate(p, loadEnvironment())
+		if err == nil && dt.kind&timestamp == 0 {
+			err = errors.New(tr("rrule needs timestamps"))
+		}
+		if err != nil {
+			return getItems(datetime{}, err)
+		}
+		dates[i] = dt.dt
+	}
+
+	start, from, to := dates[0], dates[1], dates[2]
+	if match[2] == "" && match[3] != "" {
+		// dtstart is the beginning of the period
+		start = from
+	}
+
+	items := Items{
+		Skipknowldedge: true,
+	}
+
+	var occurrences []time.Time
+	if match[3] != "" {
+		// whole days
+		to = midnight(to).AddDate(0, 0, 1).Add(-time.Second)
+		for _, t := range r.occurrences(start, to, rruleLimit) {
+			if !t.Before(from) {
+				occurrences = append(occurrences, t)
+			}
+		}
+
+		items.Items = append(items.Items, Item{
+			Title:    tr("Occurrences"),
+			Subtitle: formatInt(int64(len(occurrences))),
+			Arg:      strconv.Itoa(len(occurrences)),
+		})
+	} else {
+		n := rruleOccurrences
+		if r.count > 0 {
+			n = r.count
+		}
+		occurrences = r.occurrences(start, time.Time{}, n)
+	}
+
+	for i, t := range occurrences {
+		if i == rruleOccurrences*5 {
+			break
+		}
+		items.Items = append(items.Items, occurrenceItem(i, t))
+	}
+
+	if len(occurrences) == 0 {
+		items.Items = append(items.Items, Item{
+			Title:    tr("No occurrences"),
+			Subtitle: match[1],
+			Valid:    notValid(),
+		})
+	}
+	return items
+}
+
+// `Friday, 31 January 2025`, `#1`
+func occurrenceItem(i int, t time.Time) Item {
+	title, arg := formatDate(t), t.Format("2006-01-02")
+	if t != midnight(t) {
+		title += " " + formatClock(t)
+		arg += t.Format(" 15:04")
+	}
+
+	return Item{
+		Title:    title,
+		Subtitle: fmt.Sprintf("#%d", i+1),
+		Arg:      arg,
+	}
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRRule(t *testing.T) {
+	date := func(y int, m time.Month, d int) time.Time {
+		return time.Date(y, m, d, 0, 0, 0, 0, time.Local)
+	}
+
+	tests := []struct {
+		rule     string
+		start    time.Time
+		expected []time.Time
+	}{
+		{
+			rule:     "FREQ=MONTHLY;BYDAY=-1FR;COUNT=3",
+			start:    date(2025, 1, 1),
+			expected: []time.Time{date(2025, 1, 31), date(2025, 2, 28), date(2025, 3, 28)},
+		},
+		{
+			rule:     "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH;COUNT=4",
+			start:    date(2025, 1, 6),
+			expected: []time.Time{date(2025, 1, 6), date(2025, 1, 9), date(2025, 1, 20), date(2025, 1, 23)},
+		},
+		{
+			rule:     "FREQ=YEARLY;BYMONTH=11;BYDAY=4TH;COUNT=2",
+			start:    date(2024, 1, 1),
+			expected: []time.Time{date(2024, 11, 28), date(2025, 11, 27)},
+		},
+		{
+			rule:     "FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1;COUNT=3",
+			start:    date(2025, 1, 1),
+			expected: []time.Time{date(2025, 1, 31), date(2025, 2, 28), date(2025, 3, 31)},
+		},
+		{
+			rule:     "FREQ=MONTHLY;BYMONTHDAY=31;COUNT=3",
+			start:    date(2025, 1, 1),
+			expected: []time.Time{date(2025, 1, 31), date(2025, 3, 31), date(2025, 5, 31)},
+		},
+		{
+			rule:     "FREQ=DAILY;UNTIL=20250103",
+			start:    date(2025, 1, 1).Add(9 * time.Hour),
+			expected: []time.Time{date(2025, 1, 1).Add(9 * time.Hour), date(2025, 1, 2).Add(9 * time.Hour), date(2025, 1, 3).Add(9 * time.Hour)},
+		},
+		{
+			rule:     "FREQ=YEARLY;BYMONTHDAY=1;COUNT=3",
+			start:    date(2025, 1, 1),
+			expected: []time.Time{date(2025, 1, 1), date(2025, 2, 1), date(2025, 3, 1)},
+		},
+		{
+			// Friday the 13th
+			rule:     "FREQ=YEARLY;BYMONTHDAY=13;BYDAY=FR;COUNT=2",
+			start:    date(2025, 1, 1),
+			expected: []time.Time{date(2025, 6, 13), date(2026, 2, 13)},
+		},
+		{
+			rule:     "FREQ=YEARLY;COUNT=2",
+			start:    date(2024, 2, 29),
+			expected: []time.Time{date(2024, 2, 29), date(2028, 2, 29)},
+		},
+	}
+
+	for _, ts := range tests {
+		r, err := parseRRule(ts.rule)
+		if err != nil {
+			t.Errorf(">>> Input %s: %v\n", ts.rule, err)
+			continue
+		}
+
+		result := r.occurrences(ts.start, time.Time{}, 10)
+		if len(result) != len(ts.expected) {
+			t.Errorf(">>> Input %s: expected %v, got %v\n", ts.rule, ts.expected, result)
+			continue
+		}
+		for i := range result {
+			if !result[i].Equal(ts.expected[i]) {
+				t.Errorf(">>> Input %s: expected %v, got %v\n", ts.rule, ts.expected[i], result[i])
+			}
+		}
+	}
+
+	// 2 Mondays and 2 Thursdays of every other week in February 2025
+	items := rruleItems([]string{"", "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH", "06/01/2025", "01/02/2025", "28/02/2025"})
+	if len(items.Items) == 0 || items.Items[0].Subtitle != "4" {
+		t.Errorf(">>> Expected 4 occurrences, got %+v\n", items)
+	}
+
+	for _, rule := range []string{"BYDAY=MO", "FREQ=SECONDLY", "FREQ=DAILY;INTERVAL=0", "FREQ=MONTHLY;BYDAY=XX", "FREQ=DAILY;COUNT=0", "FREQ=DAILY;COUNT=-1"} {
+		if _, err := parseRRule(rule); err == nil {
+			t.Errorf(">>> Expected error for %s\n", rule)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// Dates listed by `every <period> from <date>` without end
+const sequenceDefault = 10
+
+// Upper limit of dates listed
+const sequenceLimit = 100
+
+// Timestamps from start, adding period each time, until end or count reached
+func dateSequence(start datetime, period datetime, end time.Time, count int) ([]time.Time, error) {
+	var dates []time.Time
+
+	t := start
+	for len(dates) < count && (end.IsZero() || !t.dt.After(end)) {
+		dates = append(dates, t.dt)
+
+		next := datetime{parameter: start.parameter}
+		if err := next.calculateDT(t, period, add); err != nil {
+			return nil, err
+		} else if next.kind&timestamp == 0 {
+			return nil, errors.New(tr("every needs a period, e.g. 2w or 5bd"))
+		}
+
+		// whole days as calendar days, `every 2w` stays at midnight across DST changes
+		if period.kind&duration != 0 {
+			days := period.ts / secondsPerDay
+			rest := time.Duration(period.ts%secondsPerDay)*time.Second + time.Duration(period.ns)
+			next.dt = t.dt.AddDate(0, 0, int(days)).Add(rest)
+		}
+
+		if !next.dt.After(t.dt) {
+			return nil, errors.New(tr("every needs a positive period"))
+		}
+		t = next
+	}
+	return dates, nil
+}
+
+// `every <period> from <date> until <date>`, `every <period> from <date> for <n>`
+func sequenceItems(match []string) Items {
+	env := loadEnvironment()
+
+	period, err := evaluate(match[1], env)
+	if err != nil {
+		return getItems(datetime{}, err)
+	}
+
+	start, err := evaluateDate(match[2], env)
+	if err == nil && start.kind&timestamp == 0 {
+		err = errors.New(tr("every needs a start date"))
+	}
+	if err != nil {
+		return getItems(datetime{}, err)
+	}
+
+	var end time.Time
+	count := sequenceDefault
+	if match[3] != "" {
+		dt, err := evaluateDate(match[3], env)
+		if err == nil && dt.kind&timestamp == 0 {
+			err = errors.New(tr("every needs an end date"))
+		}
+		if err != nil {
+			return getItems(datetime{}, err)
+		}
+		end = dt.dt
+		count = math.MaxInt
+	} else if match[4] != "" {
+		count = int(Atoi(match[4]))
+		if count < 1 {
+			return getItems(datetime{}, errors.New(tr("every needs a count of at least 1")))
+		}
+	}
+
+	// one more than the limit tells whether the list is cut
+	dates, err := dateSequence(start, period, end, min(count, sequenceLimit+1))
+	if err != nil {
+		return getItems(datetime{}, err)
+	}
+
+	truncated := len(dates) > sequenceLimit
+	if truncated {
+		dates = dates[:sequenceLimit]
+	}
+
+	items := Items{
+		Skipknowldedge: true,
+	}
+
+	var lines []string
+	for i, t := range dates {
+		item := occurrenceItem(i, t)
+		items.Items = append(items.Items, item)
+		lines = append(lines, item.Arg)
+	}
+
+	if len(dates) > 1 {
+		items.Items = append(items.Items, Item{
+			Title:    tr("Copy all dates"),
+			Subtitle: fmt.Sprintf("%s – %s", lines[0], lines[len(lines)-1]),
+			Arg:      strings.Join(lines, "\n"),
+		})
+	}
+
+	if truncated {
+		items.Items = append(items.Items, Item{
+			Title:    fmt.Sprintf(tr("Showing first %d dates"), sequenceLimit),
+			Subtitle: tr("Use a later start or an earlier end for the rest"),
+			Valid:    notValid(),
+		})
+	}
+	return items
+}
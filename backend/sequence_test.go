@@ -0,0 +1,125 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// Local time zone for the test, dates depend on DST changes
+func setLocation(t *testing.T, name string) {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Skip(err)
+	}
+	local := time.Local
+	time.Local = loc
+	t.Cleanup(func() { time.Local = local })
+}
+
+func TestDateSequence(t *testing.T) {
+	tests := []struct {
+		location string
+		input    string
+		expected []string
+	}{
+		{
+			input:    "every 2w from 06/01/2025 until 03/02/2025",
+			expected: []string{"2025-01-06", "2025-01-20", "2025-02-03"},
+		},
+		{
+			input:    "every 5bd from 22/11/2024 for 3",
+			expected: []string{"2024-11-22", "2024-11-29", "2024-12-06"},
+		},
+		{
+			input:    "every 1d12h from 01/01/2025 09:00 for 3 times",
+			expected: []string{"2025-01-01 09:00", "2025-01-02 21:00", "2025-01-04 09:00"},
+		},
+		{
+			// DST starts on 29/03/2026
+			location: "Europe/Warsaw",
+			input:    "every 2w from 16/03/2026 until 13/04/2026",
+			expected: []string{"2026-03-16", "2026-03-30", "2026-04-13"},
+		},
+		{
+			// and ends on 01/11/2026
+			location: "America/New_York",
+			input:    "every 1d from 31/10/2026 09:00 for 3",
+			expected: []string{"2026-10-31 09:00", "2026-11-01 09:00", "2026-11-02 09:00"},
+		},
+	}
+
+	for _, ts := range tests {
+		location := ts.location
+		if location == "" {
+			location = "UTC"
+		}
+		setLocation(t, location)
+
+		items, ok := runCommand(ts.input)
+
+		var result []string
+		for _, item := range items.Items {
+			result = append(result, item.Arg)
+		}
+
+		// dates followed by all of them to copy
+		expected := append(ts.expected, strings.Join(ts.expected, "\n"))
+		if !ok || strings.Join(result, "|") != strings.Join(expected, "|") {
+			t.Error(">>> Input", ts.input)
+			t.Errorf(">>> Expected %q\n", expected)
+			t.Errorf(">>> Result   %q\n", result)
+		}
+	}
+}
+
+func TestDateSequenceLimit(t *testing.T) {
+	tests := []struct {
+		input string
+		dates int
+		note  bool
+	}{
+		{input: "every 1d from 01/01/2025 until 31/12/2025", dates: sequenceLimit, note: true},
+		{input: "every 1d from 01/01/2025 for 500", dates: sequenceLimit, note: true},
+		{input: "every 1d from 01/01/2025 for 100", dates: sequenceLimit, note: false},
+		{input: "every 1d from 01/01/2025 until 10/04/2025", dates: sequenceLimit, note: false},
+	}
+
+	// 10/04/2025 is the 100th date in any time zone
+	setLocation(t, "Europe/Warsaw")
+
+	for _, ts := range tests {
+		items, _ := runCommand(ts.input)
+
+		// dates, copy all and the note
+		expected := ts.dates + 1
+		if ts.note {
+			expected++
+		}
+
+		last := items.Items[len(items.Items)-1]
+		if len(items.Items) != expected || strings.HasPrefix(last.Title, "Showing first") != ts.note {
+			t.Error(">>> Input", ts.input)
+			t.Errorf(">>> Expected %d items, note %v\n", expected, ts.note)
+			t.Errorf(">>> Result   %d items, last %+v\n", len(items.Items), last)
+		}
+	}
+}
+
+func TestDateSequenceErrors(t *testing.T) {
+	tests := []string{
+		"every 0s from 01/01/2025",
+		"every 2w from 5m",
+		"every 1d from 01/01/2025 for 0",
+	}
+
+	for _, ts := range tests {
+		items, ok := runCommand(ts)
+
+		if !ok || items.Items[0].Arg != "error" {
+			t.Error(">>> Input", ts)
+			t.Errorf(">>> Expected error\n")
+			t.Errorf(">>> Result   %+v\n", items)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+)
+
+// State kept between invocations lives in Alfred's workflow data directory
+// https://www.alfredapp.com/help/workflows/script-environment-variables/
+func dataDir() (string, error) {
+	dir := os.Getenv("alfred_workflow_data")
+	if dir == "" {
+		return "", errors.New(tr("alfred_workflow_data not set"))
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
+
+// Read JSON file from data directory into v
+// Missing file is not an error, v is left untouched
+func loadState(name string, v any) error {
+	dir, err := dataDir()
+	if err != nil {
+		return err
+	}
+
+	b, err := os.ReadFile(filepath.Join(dir, name))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(b, v)
+}
+
+// Write v as JSON file into data directory
+func saveState(name string, v any) error {
+	dir, err := dataDir()
+	if err != nil {
+		return err
+	}
+
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	// write & rename, so concurrent invocation never reads half of the file
+	tmp := filepath.Join(dir, name+".tmp")
+	if err := os.WriteFile(tmp, b, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, filepath.Join(dir, name))
+}
@@ -0,0 +1,194 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// `<hh:mm:ss:ff>`, drop-frame `<hh:mm:ss;ff>`, optionally with `@<fps>`
+var timecodeRegex = regexp.MustCompile(`^([0-9]{2}):([0-9]{2}):([0-9]{2})([:;])([0-9]{2})(?:@([0-9]+(?:\.[0-9]+)?)(?:fps)?)?$`)
+
+// ` @25fps` applies to all timecodes of the query
+var frameRateRegex = regexp.MustCompile(`(?i)\s*@([0-9]+(?:\.[0-9]+)?) ?fps\b`)
+
+// `01:00:00:00 @25fps`, when each timecode has its own frame rate
+var timecodeFrameRateRegex = regexp.MustCompile(`(?i)\b([0-9]{2}:[0-9]{2}:[0-9]{2}[:;][0-9]{2}) ?@([0-9]+(?:\.[0-9]+)?) ?fps\b`)
+
+// Frame rate of timecodes without `@<fps>`, FRAME_RATE variable
+// Drop-frame timecodes are 29.97 fps by default
+func frameRate(dropFrame bool) float64 {
+	if dropFrame {
+		return 30000.0 / 1001
+	}
+
+	fps, err := strconv.ParseFloat(getConfig("FRAME_RATE", "25"), 64)
+	if err != nil || fps <= 0 {
+		return 25
+	}
+	return fps
+}
+
+// `01:00:00:00 + 10:20 @25fps` -> `01:00:00:00@25 + 10:20`
+// `01:00:00:00 @25fps + 00:00:10:00 @30fps` -> `01:00:00:00@25 + 00:00:10:00@30`
+func applyFrameRate(p string) string {
+	matches := frameRateRegex.FindAllStringSubmatch(p, -1)
+	if len(matches) == 0 {
+		return p
+	} else if len(matches) > 1 {
+		return timecodeFrameRateRegex.ReplaceAllString(p, "${1}@${2}")
+	}
+	match := matches[0]
+	p = frameRateRegex.ReplaceAllString(p, "")
+
+	fields := strings.Fields(p)
+	for i, f := range fields {
+		if m := timecodeRegex.FindStringSubmatch(f); m != nil && m[6] == "" {
+			fields[i] += "@" + match[1]
+		}
+	}
+	return strings.Join(fields, " ")
+}
+
+// Whole frames per second, 30 for 29.97
+func nominalFrameRate(fps float64) int64 {
+	return int64(math.Round(fps))
+}
+
+// Frame numbers skipped every minute, except every 10th, 2 for 29.97
+func droppedFrames(fps float64) int64 {
+	return int64(math.Round(fps * 0.066666))
+}
+
+// 30000/1001 for 29.97
+func isDropFrameRate(fps float64) bool {
+	return math.Abs(fps-float64(nominalFrameRate(fps))*1000/1001) < 0.01
+}
+
+func parseTimecode(match []string, dt *datetime) {
+	h, m, s, f := Atoi(match[1]), Atoi(match[2]), Atoi(match[3]), Atoi(match[5])
+	dropFrame := match[4] == ";"
+
+	fps := frameRate(dropFrame)
+	if match[6] != "" {
+		fps, _ = strconv.ParseFloat(match[6], 64)
+	}
+
+	nominal := nominalFrameRate(fps)
+	if fps <= 0 || m > 59 || s > 59 || f >= nominal || (dropFrame && !isDropFrameRate(fps)) {
+		return
+	}
+
+	frames := ((h*60+m)*60+s)*nominal + f
+	if dropFrame {
+		// 00:01:00;00 and 00:01:00;01 don't exist
+		if s == 0 && m%10 != 0 && f < droppedFrames(fps) {
+			return
+		}
+		minutes := h*60 + m
+		frames -= droppedFrames(fps) * (minutes - minutes/10)
+	}
+
+	*dt = newTimecode(frames, fps, dropFrame)
+}
+
+func newTimecode(frames int64, fps float64, dropFrame bool) datetime {
+	dt := datetime{
+		kind:      timecode,
+		ts:        frames,
+		fps:       fps,
+		dropFrame: dropFrame,
+	}
+	dt.seconds = float32(dt.timecodeSeconds())
+	return dt
+}
+
+// Real time of a timecode, 29.97 fps is slower than the timecode
+func (dt datetime) timecodeSeconds() float64 {
+	if dt.fps <= 0 {
+		return 0
+	}
+	return float64(dt.ts) / dt.fps
+}
+
+// - timecode ± timecode = timecode, both with the same frame rate
+// - timecode ± duration = timecode, duration + timecode = timecode
+// - timecode * number, timecode / number = timecode
+func (dt *datetime) calculateTimecode(dt1 datetime, dt2 datetime, operation int) error {
+	if dt1.kind&duration != 0 && dt2.kind == timecode && operation == add {
+		return dt.calculateTimecode(dt2, dt1, add)
+	} else if dt1.kind != timecode {
+		return errors.New(tr("timecodes go with durations, numbers and other timecodes only"))
+	} else if dt2.kind == timecode && dt1.fps != dt2.fps {
+		return errors.New(tr("timecodes at different frame rates, e.g. @25fps and @30fps"))
+	}
+
+	var frames int64
+	if dt2.kind == timecode {
+		frames = dt2.ts
+	} else if dt2.kind&duration != 0 {
+		frames = int64(math.Round(dt2.nanoseconds().Seconds() * dt1.fps))
+	}
+
+	switch {
+	case operation == add && dt2.kind&(timecode|duration) != 0:
+		*dt = newTimecode(dt1.ts+frames, dt1.fps, dt1.dropFrame)
+		return nil
+	case operation == sub && dt2.kind&(timecode|duration) != 0:
+		*dt = newTimecode(dt1.ts-frames, dt1.fps, dt1.dropFrame)
+		return nil
+	case operation == mul && dt2.kind&number != 0:
+		*dt = newTimecode(dt1.ts*dt2.ts, dt1.fps, dt1.dropFrame)
+		return nil
+	case operation == div && dt2.kind&number != 0:
+		if dt2.ts == 0 {
+			return errors.New(tr("division by zero"))
+		}
+		*dt = newTimecode(dt1.ts/dt2.ts, dt1.fps, dt1.dropFrame)
+		return nil
+	}
+	return errors.New(tr("timecodes go with durations, numbers and other timecodes only"))
+}
+
+// `01:02:14:07`, drop-frame `01:00:00;02`
+func formatTimecode(dt datetime) string {
+	frames, sign := dt.ts, ""
+	if frames < 0 {
+		frames, sign = -frames, "-"
+	}
+
+	nominal := nominalFrameRate(dt.fps)
+	if nominal <= 0 {
+		return ""
+	}
+	separator := ":"
+
+	if dt.dropFrame {
+		drop := droppedFrames(dt.fps)
+		perMinute := nominal*60 - drop
+		perTenMinutes := perMinute*10 + drop
+
+		tens, rest := frames/perTenMinutes, frames%perTenMinutes
+		frames += drop * 9 * tens
+		if rest > drop {
+			frames += drop * ((rest - drop) / perMinute)
+		}
+		separator = ";"
+	}
+
+	f := frames % nominal
+	s := frames / nominal
+	return fmt.Sprintf("%s%02d:%02d:%02d%s%02d", sign, s/3600, s%3600/60, s%60, separator, f)
+}
+
+// `25 fps`, `29.97 fps drop-frame`
+func formatFrameRate(dt datetime) string {
+	s := strconv.FormatFloat(math.Round(dt.fps*100)/100, 'f', -1, 64) + " fps"
+	if dt.dropFrame {
+		s += " " + tr("drop-frame")
+	}
+	return s
+}
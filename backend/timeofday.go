@@ -0,0 +1,103 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const secondsPerDay = 24 * 3600
+
+// Time of day of given number of seconds since midnight of the start day,
+// past 24:00 (or negative) when the day rolled over
+func newTimeOfDay(s int64) datetime {
+	dt := datetime{
+		kind: timeOfDay,
+		ts:   s,
+	}
+	dt.dt = clockTime(s)
+	return dt
+}
+
+// Clock time on the 1st of January of year 0,
+// only hour, minute and second are meaningful
+func clockTime(s int64) time.Time {
+	return time.Date(0, 1, 1, 0, 0, 0, 0, time.UTC).Add(time.Duration(floorMod(s, secondsPerDay)) * time.Second)
+}
+
+// Days the time of day is past (or before) the start day
+func rolloverDays(s int64) int64 {
+	return (s - floorMod(s, secondsPerDay)) / secondsPerDay
+}
+
+// Modulo with a non-negative result, -1 % 86400 is 86399
+func floorMod(a, b int64) int64 {
+	return ((a % b) + b) % b
+}
+
+// - time - time = duration
+// - time + duration = time, duration + time = time
+// - time - duration = time
+func (dt *datetime) calculateTimeOfDay(dt1 datetime, dt2 datetime, operation int) error {
+	if dt1.kind == timeOfDay && dt2.kind == timeOfDay && operation == sub {
+		// 17:30 - 09:00 -> 8h30m
+		*dt = newDuration(dt1.ts - dt2.ts)
+	} else if dt1.kind == timeOfDay && dt2.kind&duration != 0 && operation == add {
+		// 23:00 + 2h -> 01:00 next day
+		*dt = newTimeOfDay(dt1.ts + dt2.ts)
+	} else if dt1.kind&duration != 0 && dt2.kind == timeOfDay && operation == add {
+		*dt = newTimeOfDay(dt1.ts + dt2.ts)
+	} else if dt1.kind == timeOfDay && dt2.kind&duration != 0 && operation == sub {
+		*dt = newTimeOfDay(dt1.ts - dt2.ts)
+	} else {
+		return errors.New(tr("a time of day goes with durations and other times of day only"))
+	}
+	return nil
+}
+
+// `01:00:00 (+1 day)`
+func formatTimeOfDay(dt datetime) string {
+	s := formatClock(clockTime(dt.ts))
+
+	if days := rolloverDays(dt.ts); days > 0 {
+		s += fmt.Sprintf(" (+%s)", formatUnit(days, "day"))
+	} else if days < 0 {
+		s += fmt.Sprintf(" (-%s)", formatUnit(-days, "day"))
+	}
+	return s
+}
+
+// `from 22:00 to 06:30`, `22:00 to 06:30` and `22:00 .. 06:30` -> `22:00..06:30`
+var clockRangeRegex = regexp.MustCompile(`(?i)(?:\bfrom )?(@?[0-9]{1,2}(?::[0-9]{2}){0,2}(?: ?[ap]\.?m\.?)?) ?(?:\.\.|\bto\b) ?(@?[0-9]{1,2}(?::[0-9]{2}){0,2}(?: ?[ap]\.?m\.?)?)`)
+
+func normalizeClockRanges(p string) string {
+	return clockRangeRegex.ReplaceAllStringFunc(p, func(r string) string {
+		match := clockRangeRegex.FindStringSubmatch(r)
+		if _, ok := parseClockRange(match[1] + ".." + match[2]); !ok {
+			return r
+		}
+		return strings.ReplaceAll(match[1], " ", "") + ".." + strings.ReplaceAll(match[2], " ", "")
+	})
+}
+
+// Duration between two clock times, past midnight if the end is earlier,
+// e.g. `22:00..06:30` is 8h30m
+func parseClockRange(f string) (int64, bool) {
+	from, to, found := strings.Cut(f, "..")
+	if !found {
+		return 0, false
+	}
+
+	start, ok1 := parseClock(strings.TrimPrefix(from, "@"))
+	end, ok2 := parseClock(strings.TrimPrefix(to, "@"))
+	if !ok1 || !ok2 {
+		return 0, false
+	}
+
+	if end < start {
+		end += secondsPerDay * time.Second
+	}
+	return int64((end - start).Seconds()), true
+}
@@ -0,0 +1,202 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+const timersFile = "timers.json"
+
+// Stopwatch started with `start <name>`
+type timer struct {
+	Start time.Time   `json:"start"`
+	Laps  []time.Time `json:"laps,omitempty"`
+	Stop  time.Time   `json:"stop,omitempty"` // zero while running
+}
+
+func (t timer) running() bool {
+	return t.Stop.IsZero()
+}
+
+// Time from start until now, or until stopped
+func (t timer) elapsed() time.Duration {
+	if t.running() {
+		return timeNow().Sub(t.Start)
+	}
+	return t.Stop.Sub(t.Start)
+}
+
+func loadTimers() (map[string]timer, error) {
+	timers := map[string]timer{}
+	err := loadState(timersFile, &timers)
+	return timers, err
+}
+
+// Timers usable in expressions as elapsed duration
+func loadTimerEnvironment() environment {
+	env := environment{}
+
+	timers, err := loadTimers()
+	if err != nil {
+		return env
+	}
+
+	for name, t := range timers {
+		env[name] = newDuration(int64(t.elapsed().Seconds()))
+	}
+	return env
+}
+
+func startTimer(name string) error {
+	if reservedNames[name] {
+		return fmt.Errorf(tr("%s is a reserved name"), name)
+	}
+
+	timers, err := loadTimers()
+	if err != nil {
+		return err
+	}
+
+	timers[name] = timer{
+		Start: timeNow().Round(time.Second),
+	}
+	return saveState(timersFile, timers)
+}
+
+// Record lap or stop the timer
+func updateTimer(name string, stop bool) error {
+	timers, err := loadTimers()
+	if err != nil {
+		return err
+	}
+
+	t, ok := timers[name]
+	if !ok {
+		return fmt.Errorf(tr("unknown timer %s"), name)
+	} else if !t.running() {
+		return fmt.Errorf(tr("timer %s already stopped"), name)
+	}
+
+	now := timeNow().Round(time.Second)
+	if stop {
+		t.Stop = now
+	} else {
+		t.Laps = append(t.Laps, now)
+	}
+
+	timers[name] = t
+	return saveState(timersFile, timers)
+}
+
+func deleteTimer(name string) error {
+	timers, err := loadTimers()
+	if err != nil {
+		return err
+	}
+
+	if _, ok := timers[name]; !ok {
+		return fmt.Errorf(tr("unknown timer %s"), name)
+	}
+
+	delete(timers, name)
+	return saveState(timersFile, timers)
+}
+
+// Action item followed by elapsed time in all duration formats and laps
+func timerItems(name string, title string, arg string) Items {
+	timers, err := loadTimers()
+	if err != nil {
+		return getItems(datetime{}, err)
+	}
+
+	t, ok := timers[name]
+	if !ok && arg != actionPrefix+"start "+name {
+		return getItems(datetime{}, fmt.Errorf(tr("unknown timer %s"), name))
+	}
+
+	items := Items{
+		Skipknowldedge: true,
+	}
+
+	subtitle := tr("Press Enter to confirm")
+	if ok {
+		state := tr("running")
+		if !t.running() {
+			state = tr("stopped")
+		}
+		subtitle = fmt.Sprintf(tr("Timer %s %s, started %s"), name, state, formatDate(t.Start)+" "+formatClock(t.Start))
+	}
+
+	items.Items = append(items.Items, Item{
+		Title:    title,
+		Subtitle: subtitle,
+		Arg:      arg,
+	})
+
+	if !ok {
+		return items
+	}
+
+	items.Items = append(items.Items, resultItems(newDuration(int64(t.elapsed().Seconds())))...)
+
+	// Laps, with split since previous lap
+	previous := t.Start
+	for i, lap := range t.Laps {
+		split := formatResult(newDuration(int64(lap.Sub(previous).Seconds())))
+		total := formatResult(newDuration(int64(lap.Sub(t.Start).Seconds())))
+
+		items.Items = append(items.Items, Item{
+			Title:    fmt.Sprintf(tr("Lap %d: %s"), i+1, split),
+			Subtitle: fmt.Sprintf(tr("Total %s"), total),
+			Arg:      split,
+		})
+		previous = lap
+	}
+
+	return items
+}
+
+func timersListItems(match []string) Items {
+	items := Items{
+		Skipknowldedge: true,
+	}
+
+	timers, err := loadTimers()
+	if err != nil {
+		return getItems(datetime{}, err)
+	}
+
+	names := make([]string, 0, len(timers))
+	for name := range timers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		t := timers[name]
+		state, next := tr("running"), "lap "
+		if !t.running() {
+			state, next = tr("stopped"), "reset "
+		}
+
+		result := formatResult(newDuration(int64(t.elapsed().Seconds())))
+		items.Items = append(items.Items, Item{
+			Uid:          "timer " + name,
+			Title:        fmt.Sprintf("%s (%s)", name, state),
+			Subtitle:     result,
+			Arg:          result,
+			Autocomplete: next + name,
+		})
+	}
+
+	if len(items.Items) == 0 {
+		items.Items = append(items.Items, Item{
+			Title:    tr("No timers"),
+			Subtitle: tr("Start one with start <name>, e.g. start build"),
+			Arg:      "",
+			Valid:    notValid(),
+		})
+	}
+	return items
+}
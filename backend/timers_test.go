@@ -0,0 +1,44 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimers(t *testing.T) {
+	t.Setenv("alfred_workflow_data", t.TempDir())
+
+	now := time.Date(2024, 11, 22, 10, 0, 0, 0, time.UTC)
+	timeNow = func() time.Time { return now }
+	defer func() { timeNow = time.Now }()
+
+	if err := startTimer("build"); err != nil {
+		t.Fatal(err)
+	}
+
+	now = now.Add(90 * time.Second)
+	updateTimer("build", false)
+
+	now = now.Add(30 * time.Second)
+	result, err := evaluate("build + 10m", loadEnvironment())
+	if err != nil || result.kind != duration || result.ts != 12*60 {
+		t.Errorf(">>> Expected 12m, got %+v (%v)\n", result, err)
+	}
+
+	updateTimer("build", true)
+	now = now.Add(time.Hour)
+
+	timers, _ := loadTimers()
+	if timers["build"].elapsed() != 2*time.Minute || len(timers["build"].Laps) != 1 {
+		t.Errorf(">>> Unexpected stopped timer %+v\n", timers["build"])
+	}
+
+	if err := updateTimer("build", false); err == nil {
+		t.Error(">>> Expected error for lap of stopped timer")
+	}
+
+	deleteTimer("build")
+	if _, err := evaluate("build + 10m", loadEnvironment()); err == nil {
+		t.Error(">>> Expected error for deleted timer")
+	}
+}
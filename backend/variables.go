@@ -0,0 +1,148 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+const variablesFile = "variables.json"
+
+// Variable as stored on disk
+type storedValue struct {
+	Kind  int       `json:"kind"`
+	Ts    int64     `json:"ts"`
+	Per   string    `json:"per,omitempty"`
+	Time  time.Time `json:"time,omitempty"`
+	Query string    `json:"query"`
+
+	// frame rate of a timecode
+	Fps       float64 `json:"fps,omitempty"`
+	DropFrame bool    `json:"dropFrame,omitempty"`
+
+	Ns    int64  `json:"ns,omitempty"`    // nanoseconds of a duration
+	Token string `json:"token,omitempty"` // JWT
+	Note  string `json:"note,omitempty"`  // how the input was read
+}
+
+func toStoredValue(dt datetime, query string) storedValue {
+	v := storedValue{
+		Kind:  dt.kind,
+		Ts:    dt.ts,
+		Per:   dt.per,
+		Query: query,
+
+		Fps:       dt.fps,
+		DropFrame: dt.dropFrame,
+
+		Ns:    dt.ns,
+		Token: dt.token,
+		Note:  dt.note,
+	}
+
+	if dt.kind&timestamp != 0 {
+		v.Time = dt.dt
+	}
+	return v
+}
+
+func (v storedValue) datetime() datetime {
+	dt := datetime{
+		kind: v.Kind,
+		ts:   v.Ts,
+	}
+
+	if v.Kind == timecode {
+		dt = newTimecode(v.Ts, v.Fps, v.DropFrame)
+	} else if v.Kind == timeOfDay {
+		dt = newTimeOfDay(v.Ts)
+	} else if v.Kind&timestamp != 0 {
+		dt.dt = v.Time
+	} else {
+		dt.updateDT(ts)
+	}
+
+	dt.ns = v.Ns
+	dt.per = v.Per
+	dt.token = v.Token
+	dt.note = v.Note
+	return dt
+}
+
+func loadStoredVariables() (map[string]storedValue, error) {
+	vars := map[string]storedValue{}
+	err := loadState(variablesFile, &vars)
+	return vars, err
+}
+
+// Variables usable in expressions
+// Without data directory (e.g. when run outside of Alfred) there are none
+func loadVariables() environment {
+	env := environment{}
+
+	vars, err := loadStoredVariables()
+	if err != nil {
+		return env
+	}
+
+	for name, v := range vars {
+		env[name] = v.datetime()
+	}
+	return env
+}
+
+// Everything which can be referenced by name: timers and variables
+// Variables take precedence over timers with the same name
+func loadEnvironment() environment {
+	env := loadTimerEnvironment()
+	for name, v := range loadVariables() {
+		env[name] = v
+	}
+	return env
+}
+
+// Evaluate `<name> = <expr>` and store the result
+func saveVariable(p string) (string, error) {
+	name, expr, ok := parseAssignment(p)
+	if !ok {
+		return "", errors.New(tr("allowed format: <name> = <expr>"))
+	}
+
+	vars, err := loadStoredVariables()
+	if err != nil {
+		return "", err
+	}
+
+	dt, err := evaluate(p, loadEnvironment())
+	if err != nil {
+		return "", err
+	}
+
+	vars[name] = toStoredValue(dt, expr)
+	return name, saveState(variablesFile, vars)
+}
+
+func deleteVariable(name string) error {
+	vars, err := loadStoredVariables()
+	if err != nil {
+		return err
+	}
+
+	if _, ok := vars[name]; !ok {
+		return fmt.Errorf(tr("unknown variable %s"), name)
+	}
+
+	delete(vars, name)
+	return saveState(variablesFile, vars)
+}
+
+// Names of stored variables, sorted
+func variableNames(vars map[string]storedValue) []string {
+	names := make([]string, 0, len(vars))
+	for name := range vars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestVariables(t *testing.T) {
+	t.Setenv("alfred_workflow_data", t.TempDir())
+
+	timeNow = func() time.Time { return time.Unix(1700000000, 0) }
+	defer func() { timeNow = time.Now }()
+
+	// {"alg":"HS256","typ":"JWT"}.{"sub":"1","iat":1699989200,"nbf":1699989200,"exp":1700000720}
+	token := "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9.eyJzdWIiOiIxIiwiaWF0IjoxNjk5OTg5MjAwLCJuYmYiOjE2OTk5ODkyMDAsImV4cCI6MTcwMDAwMDcyMH0.c2ln-_"
+
+	tests := []struct {
+		name string
+		expr string
+	}{
+		{name: "standup", expr: "15m"},
+		{name: "p", expr: "350ms"},
+		{name: "n", expr: "6"},
+		{name: "deadline", expr: "24/12/2024 18:00"},
+		{name: "id", expr: "01ARZ3NDEKTSV4RRFFQ69G5FAV"},
+		{name: "token", expr: token},
+		{name: "shift", expr: "@22:00 + 10h"},
+		{name: "rate", expr: "800/d"},
+		{name: "fee", expr: "2h * 120/h"},
+		{name: "days", expr: "5bd"},
+	}
+
+	for _, ts := range tests {
+		if name, err := saveVariable(ts.name + " = " + ts.expr); err != nil || name != ts.name {
+			t.Fatalf(">>> Cannot save %s: %v\n", ts.name, err)
+		}
+	}
+
+	env := loadVariables()
+	for _, ts := range tests {
+		expected, _ := evaluate(ts.expr, nil)
+
+		var want, got []string
+		for _, item := range resultItems(expected) {
+			want = append(want, item.Subtitle)
+		}
+		for _, item := range resultItems(env[ts.name]) {
+			got = append(got, item.Subtitle)
+		}
+
+		if strings.Join(got, "|") != strings.Join(want, "|") {
+			t.Error(">>> Input", ts.name, ts.expr)
+			t.Errorf(">>> Expected %q\n", want)
+			t.Errorf(">>> Result   %q\n", got)
+		}
+	}
+
+	if dt, err := evaluate("p * 2", loadEnvironment()); err != nil || dt.nanoseconds() != 700*time.Millisecond {
+		t.Errorf(">>> Expected 700ms, got %+v (%v)\n", dt, err)
+	}
+
+	items, _ := runCommand("vars")
+	if len(items.Items) != len(tests) || items.Items[0].Title != "days = 5bd" || items.Items[len(tests)-1].Title != "token = "+token {
+		t.Errorf(">>> Unexpected variables %+v\n", items)
+	}
+
+	for _, ts := range tests {
+		if err := deleteVariable(ts.name); err != nil {
+			t.Errorf(">>> Cannot delete %s: %v\n", ts.name, err)
+		}
+	}
+
+	if err := deleteVariable("standup"); err == nil {
+		t.Error(">>> Expected error for deleted variable")
+	}
+
+	if items, _ := runCommand("vars"); len(items.Items) != 1 || items.Items[0].Title != "No variables" {
+		t.Errorf(">>> Expected no variables, got %+v\n", items)
+	}
+
+	for _, name := range []string{"now", "week", "sprint", "vars", "timers", "holidays", "cron", "rrule", "every", "start", "lap", "stop", "reset", "del", "bd", "workdays"} {
+		if _, err := saveVariable(name + " = 5m"); err == nil {
+			t.Errorf(">>> Expected error for reserved name %s\n", name)
+		}
+	}
+
+	// shown in `Save as`
+	if dt, err := evaluate("x = 5", nil); err != nil || formatResult(dt) != "5" {
+		t.Errorf(">>> Expected 5, got %+v (%v)\n", dt, err)
+	}
+}
+
+func TestStoredTimecode(t *testing.T) {
+	t.Setenv("alfred_workflow_data", t.TempDir())
+	t.Setenv("FRAME_RATE", "")
+
+	for _, p := range []string{"tc = 01:00:00:00 @30fps", "df = 01:00:00;00"} {
+		if _, err := saveVariable(p); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{input: "tc", expected: "01:00:00:00"},
+		{input: "tc + 1s", expected: "01:00:01:00"},
+		{input: "df", expected: "01:00:00;00"},
+	}
+
+	for _, ts := range tests {
+		dt, err := evaluate(ts.input, loadEnvironment())
+
+		if err != nil || dt.kind != timecode || formatTimecode(dt) != ts.expected {
+			t.Error(">>> Input", ts.input)
+			t.Errorf(">>> Expected %s\n", ts.expected)
+			t.Errorf(">>> Result   %s %+v %v\n", formatTimecode(dt), dt, err)
+		}
+	}
+
+	if dt := loadVariables()["tc"]; dt.fps != 30 || loadVariables()["df"].dropFrame != true {
+		t.Errorf(">>> Expected frame rates kept, got %+v\n", dt)
+	}
+
+	if items, _ := runCommand("vars"); len(items.Items) != 2 {
+		t.Errorf(">>> Expected 2 variables, got %+v\n", items)
+	}
+
+	// saved without a frame rate by an earlier version
+	if s := formatTimecode(storedValue{Kind: timecode, Ts: 25}.datetime()); s != "" {
+		t.Errorf(">>> Expected no timecode without frame rate, got %s\n", s)
+	}
+}
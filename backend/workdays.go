@@ -0,0 +1,153 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+func isWeekend(t time.Time) bool {
+	for _, wd := range weekend() {
+		if t.Weekday() == wd {
+			return true
+		}
+	}
+	return false
+}
+
+// Neither weekend nor a holiday
+func isBusinessDay(t time.Time) bool {
+	if isWeekend(t) {
+		return false
+	}
+
+	_, holiday := holidays().holiday(t)
+	return !holiday
+}
+
+// Move by n business days, skipping weekends, time of day is kept
+// Start day is not counted, so Fri + 1bd is Mon
+func addBusinessDays(t time.Time, n int64) time.Time {
+	step := 1
+	if n < 0 {
+		step, n = -1, -n
+	}
+
+	for n > 0 {
+		t = t.AddDate(0, 0, step)
+		if isBusinessDay(t) {
+			n--
+		}
+	}
+	return t
+}
+
+// Number of business days from one date to another, both included
+func countBusinessDays(from, to time.Time) int64 {
+	from, to = midnight(from), midnight(to)
+
+	sign := int64(1)
+	if to.Before(from) {
+		from, to = to, from
+		sign = -1
+	}
+
+	var n int64
+	for t := from; !t.After(to); t = t.AddDate(0, 0, 1) {
+		if isBusinessDay(t) {
+			n++
+		}
+	}
+	return sign * n
+}
+
+// Arithmetic where one side is in business days
+func (dt *datetime) calculateBusinessDays(dt1 datetime, dt2 datetime, operation int) error {
+	if err := holidays().err; err != nil {
+		return err
+	}
+
+	if dt2.kind == businessDays && dt1.kind&timestamp != 0 && (operation == add || operation == sub) {
+		// 22/11/2024 + 10bd -> timestamp
+		n := dt2.ts
+		if operation == sub {
+			n = -n
+		}
+		dt.kind = timestamp
+		dt.dt = addBusinessDays(dt1.dt, n)
+	} else if dt1.kind == businessDays && dt2.kind&timestamp != 0 && operation == add {
+		// 10bd + 22/11/2024 -> timestamp
+		return dt.calculateBusinessDays(dt2, dt1, add)
+	} else if dt1.kind == businessDays && dt2.kind == businessDays && (operation == add || operation == sub) {
+		// 5bd + 2bd -> 7bd
+		dt.kind = businessDays
+		dt.ts = dt1.ts + dt2.ts
+		if operation == sub {
+			dt.ts = dt1.ts - dt2.ts
+		}
+	} else if operation == mul && (dt1.kind&number != 0 || dt2.kind&number != 0) {
+		// 2 * 5bd -> 10bd
+		dt.kind = businessDays
+		dt.ts = dt1.ts * dt2.ts
+	} else if operation == div && dt1.kind == businessDays && dt2.kind&number != 0 {
+		// 10bd / 2 -> 5bd
+		if dt2.ts == 0 {
+			return errors.New(tr("division by zero"))
+		}
+		dt.kind = businessDays
+		dt.ts = dt1.ts / dt2.ts
+	} else {
+		return errors.New(tr("business days go with dates, other business days and numbers only"))
+	}
+	return nil
+}
+
+// `bd between <date> and <date>`, `workdays <date> and <date>`
+func businessDaysItems(match []string) Items {
+	var dates [2]time.Time
+
+	for i, p := range match[2:] {
+		dt, err := evaluateDate(p, loadEnvironment())
+		if err == nil && dt.kind != timestamp {
+			err = fmt.Errorf(tr("%s needs two dates"), match[1])
+		}
+		if err != nil {
+			return getItems(datetime{}, err)
+		}
+		dates[i] = dt.dt
+	}
+
+	if err := holidays().err; err != nil {
+		return getItems(datetime{}, err)
+	}
+
+	n := countBusinessDays(dates[0], dates[1])
+	calendar := int64(midnight(dates[1]).Sub(midnight(dates[0])).Hours()+12) / 24
+
+	items := getItems(datetime{kind: businessDays, ts: n}, nil)
+	extra := []Item{
+		{
+			Title:    tr("Calendar days"),
+			Subtitle: formatUnit(calendar, "day"),
+			Arg:      formatUnit(calendar, "day"),
+		},
+	}
+
+	// Holidays excluded, other than on weekends
+	from, to := midnight(dates[0]), midnight(dates[1])
+	if to.Before(from) {
+		from, to = to, from
+	}
+	for t := from; !t.After(to); t = t.AddDate(0, 0, 1) {
+		if name, ok := holidays().holiday(t); ok && !isWeekend(t) {
+			extra = append(extra, Item{
+				Title:    tr("Holiday") + ": " + name,
+				Subtitle: formatDate(t),
+				Arg:      t.Format("2006-01-02"),
+			})
+		}
+	}
+
+	items.Items = append(items.Items[:1], append(extra, items.Items[1:]...)...)
+	return items
+}
@@ -0,0 +1,167 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestBusinessDays(t *testing.T) {
+	tests := []struct {
+		input    string
+		weekend  string
+		expected time.Time
+	}{
+		{input: "22/11/2024 + 10bd", expected: time.Date(2024, 12, 6, 0, 0, 0, 0, time.Local)},
+		{input: "22/11/2024 18:00 + 1bd", expected: time.Date(2024, 11, 25, 18, 0, 0, 0, time.Local)},
+		{input: "25/11/2024 - 1bd", expected: time.Date(2024, 11, 22, 0, 0, 0, 0, time.Local)},
+		{input: "2 * 3bd + 23/11/2024", expected: time.Date(2024, 12, 2, 0, 0, 0, 0, time.Local)},
+		{input: "21/11/2024 + 1bd", weekend: "2", expected: time.Date(2024, 11, 24, 0, 0, 0, 0, time.Local)},
+		{input: "22/11 + 10bd", expected: time.Date(2024, 12, 6, 0, 0, 0, 0, time.Local)},
+		{input: "10bd + 22/11", expected: time.Date(2024, 12, 6, 0, 0, 0, 0, time.Local)},
+		{input: "25/11 - 1bd", expected: time.Date(2024, 11, 22, 0, 0, 0, 0, time.Local)},
+	}
+
+	// `<DD>/<MM>` in the current year
+	timeNow = func() time.Time { return time.Date(2024, 11, 20, 10, 0, 0, 0, time.Local) }
+	defer func() { timeNow = time.Now }()
+
+	for _, ts := range tests {
+		t.Setenv("WEEKEND", ts.weekend)
+
+		result, err := evaluate(ts.input, nil)
+		if err != nil || result.kind != timestamp || !result.dt.Equal(ts.expected) {
+			t.Error(">>> Input", ts.input)
+			t.Errorf(">>> Expected %v\n", ts.expected)
+			t.Errorf(">>> Result   %v %v\n", result.dt, err)
+		}
+	}
+
+	t.Setenv("WEEKEND", "")
+
+	for _, input := range []string{"1h + 5bd", "5bd - now", "10bd / 0"} {
+		if result, err := evaluate(input, nil); err == nil {
+			t.Errorf(">>> Expected error for input: >%s<, got %+v\n", input, result)
+		}
+	}
+
+	from := time.Date(2024, 11, 1, 0, 0, 0, 0, time.Local)
+	to := time.Date(2024, 11, 30, 0, 0, 0, 0, time.Local)
+
+	if n := countBusinessDays(from, to); n != 21 {
+		t.Errorf(">>> Expected 21 business days in November 2024, got %d\n", n)
+	}
+	if n := countBusinessDays(to, from); n != -21 {
+		t.Errorf(">>> Expected -21 business days, got %d\n", n)
+	}
+}
+
+func TestHolidays(t *testing.T) {
+	easter := map[int]time.Time{
+		2024: time.Date(2024, 3, 31, 0, 0, 0, 0, time.Local),
+		2025: time.Date(2025, 4, 20, 0, 0, 0, 0, time.Local),
+		2038: time.Date(2038, 4, 25, 0, 0, 0, 0, time.Local),
+	}
+	for year, expected := range easter {
+		if result := easterSunday(year); !result.Equal(expected) {
+			t.Errorf(">>> Easter %d: expected %v, got %v\n", year, expected, result)
+		}
+	}
+
+	dir := t.TempDir()
+	t.Setenv("alfred_workflow_data", dir)
+	t.Setenv("HOLIDAYS", "PL, us")
+	t.Setenv("WEEKEND", "")
+
+	os.MkdirAll(filepath.Join(dir, holidaysDir), 0755)
+	os.WriteFile(filepath.Join(dir, holidaysDir, "company.csv"), []byte("date,name\n2024-11-29,Company day\n02/01,Bridge day\n"), 0644)
+	os.WriteFile(filepath.Join(dir, holidaysDir, "office.ics"), []byte(
+		"BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nDTSTART;VALUE=DATE:20241230\r\nDTEND;VALUE=DATE:20250101\r\nSUMMARY:Office\r\n  closed\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n"), 0644)
+
+	tests := []struct {
+		date time.Time
+		name string
+	}{
+		{time.Date(2024, 5, 30, 0, 0, 0, 0, time.Local), "Corpus Christi"},
+		{time.Date(2024, 11, 28, 0, 0, 0, 0, time.Local), "Thanksgiving Day"},
+		{time.Date(2024, 5, 27, 0, 0, 0, 0, time.Local), "Memorial Day"},
+		{time.Date(2024, 11, 29, 0, 0, 0, 0, time.Local), "Company day"},
+		{time.Date(2026, 1, 2, 0, 0, 0, 0, time.Local), "Bridge day"},
+		{time.Date(2024, 12, 31, 0, 0, 0, 0, time.Local), "Office closed"},
+		{time.Date(2024, 12, 25, 0, 0, 0, 0, time.Local), "Christmas Day"},
+		{time.Date(2024, 12, 24, 0, 0, 0, 0, time.Local), ""},
+		{time.Date(2025, 12, 24, 0, 0, 0, 0, time.Local), "Christmas Eve"},
+	}
+
+	for _, ts := range tests {
+		name, _ := holidays().holiday(ts.date)
+		if name != ts.name {
+			t.Errorf(">>> %v: expected %q, got %q\n", ts.date, ts.name, name)
+		}
+	}
+
+	// Mon 23/12, 24/12 and 27/12, skipping 25-26/12, 30-31/12 and 01-02/01
+	result, err := evaluate("20/12/2024 + 4bd", nil)
+	expected := time.Date(2025, 1, 3, 0, 0, 0, 0, time.Local)
+	if err != nil || !result.dt.Equal(expected) {
+		t.Errorf(">>> Expected %v, got %v (%v)\n", expected, result.dt, err)
+	}
+
+	for _, content := range []string{"date,name\nfriday,Weekly\n", "date,name\n2024-11-31,Typo\n", "\"unterminated\n"} {
+		os.WriteFile(filepath.Join(dir, holidaysDir, "company.csv"), []byte(content), 0644)
+		t.Setenv("HOLIDAYS", "PL")
+		holidaysLoaded = nil
+
+		if _, err := evaluate("20/12/2024 + 4bd", nil); err == nil {
+			t.Errorf(">>> Expected error for holidays file %q\n", content)
+		}
+	}
+}
+
+func TestWorkTime(t *testing.T) {
+	t.Setenv("alfred_workflow_data", t.TempDir())
+	t.Setenv("HOLIDAYS", "")
+	t.Setenv("WEEKEND", "")
+
+	tests := []struct {
+		input    string
+		lunch    string
+		expected time.Time
+	}{
+		{input: "22/11/2024 15:00 + 6 work hours", expected: time.Date(2024, 11, 25, 13, 0, 0, 0, time.Local)},
+		{input: "22/11/2024 15:00 + 6 work hours", lunch: "12:00-12:30", expected: time.Date(2024, 11, 25, 13, 30, 0, 0, time.Local)},
+		{input: "22/11/2024 07:00 + 8wh", expected: time.Date(2024, 11, 22, 17, 0, 0, 0, time.Local)},
+		{input: "22/11/2024 18:00 + 30 working minutes", expected: time.Date(2024, 11, 25, 9, 30, 0, 0, time.Local)},
+		{input: "25/11/2024 10:00 - 2wh", expected: time.Date(2024, 11, 22, 16, 0, 0, 0, time.Local)},
+		{input: "25/11/2024 10:00 - 1wh", expected: time.Date(2024, 11, 25, 9, 0, 0, 0, time.Local)},
+		{input: "22/11 15:00 + 6 work hours", expected: time.Date(2024, 11, 25, 13, 0, 0, 0, time.Local)},
+	}
+
+	timeNow = func() time.Time { return time.Date(2024, 11, 20, 10, 0, 0, 0, time.Local) }
+	defer func() { timeNow = time.Now }()
+
+	for _, ts := range tests {
+		t.Setenv("LUNCH_BREAK", ts.lunch)
+
+		result, err := evaluate(ts.input, nil)
+		if err != nil || result.kind != timestamp || !result.dt.Equal(ts.expected) {
+			t.Error(">>> Input", ts.input, ts.lunch)
+			t.Errorf(">>> Expected %v\n", ts.expected)
+			t.Errorf(">>> Result   %v %v\n", result.dt, err)
+		}
+	}
+
+	for _, input := range []string{"8wh * 1h", "8wh - now", "8wh / 0"} {
+		if result, err := evaluate(input, nil); err == nil {
+			t.Errorf(">>> Expected error for input: >%s<, got %+v\n", input, result)
+		}
+	}
+
+	t.Setenv("LUNCH_BREAK", "12:00-13:00")
+	from := time.Date(2024, 11, 22, 11, 0, 0, 0, time.Local)
+	to := time.Date(2024, 11, 26, 10, 0, 0, 0, time.Local)
+	if d := workTimeBetween(from, to); d != 13*time.Hour {
+		t.Errorf(">>> Expected 13h of work, got %v\n", d)
+	}
+}
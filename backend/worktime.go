@@ -0,0 +1,166 @@
+package main
+
+import (
+	"errors"
+	"time"
+)
+
+// Working periods of a day, none for weekends and holidays
+func workIntervals(day time.Time) [][2]time.Time {
+	if !isBusinessDay(day) {
+		return nil
+	}
+
+	day = midnight(day)
+	start, end, lunchStart, lunchEnd := workSchedule()
+
+	if lunchStart == lunchEnd {
+		return [][2]time.Time{{day.Add(start), day.Add(end)}}
+	}
+	return [][2]time.Time{
+		{day.Add(start), day.Add(lunchStart)},
+		{day.Add(lunchEnd), day.Add(end)},
+	}
+}
+
+// Move by d of working time, e.g. Fri 15:00 + 6h is Mon 13:00 for 09:00-17:00
+func addWorkTime(t time.Time, d time.Duration) time.Time {
+	if d < 0 {
+		return subWorkTime(t, -d)
+	}
+
+	// bounded, so misconfiguration can't loop forever
+	for day, n := midnight(t), 0; n < 3660; day, n = day.AddDate(0, 0, 1), n+1 {
+		for _, iv := range workIntervals(day) {
+			if !iv[1].After(t) {
+				continue
+			}
+
+			start := iv[0]
+			if t.After(start) {
+				start = t
+			}
+
+			if available := iv[1].Sub(start); d <= available {
+				return start.Add(d)
+			} else {
+				d -= available
+			}
+		}
+	}
+	return t
+}
+
+func subWorkTime(t time.Time, d time.Duration) time.Time {
+	for day, n := midnight(t), 0; n < 3660; day, n = day.AddDate(0, 0, -1), n+1 {
+		intervals := workIntervals(day)
+
+		for i := len(intervals) - 1; i >= 0; i-- {
+			iv := intervals[i]
+			if !iv[0].Before(t) {
+				continue
+			}
+
+			end := iv[1]
+			if t.Before(end) {
+				end = t
+			}
+
+			if available := end.Sub(iv[0]); d <= available {
+				return end.Add(-d)
+			} else {
+				d -= available
+			}
+		}
+	}
+	return t
+}
+
+// Working time between two timestamps, negative if to is before from
+func workTimeBetween(from, to time.Time) time.Duration {
+	sign := time.Duration(1)
+	if to.Before(from) {
+		from, to = to, from
+		sign = -1
+	}
+
+	var d time.Duration
+	for day := midnight(from); day.Before(to); day = day.AddDate(0, 0, 1) {
+		for _, iv := range workIntervals(day) {
+			start, end := iv[0], iv[1]
+			if from.After(start) {
+				start = from
+			}
+			if to.Before(end) {
+				end = to
+			}
+			if end.After(start) {
+				d += end.Sub(start)
+			}
+		}
+	}
+	return sign * d
+}
+
+// Arithmetic where one side is working time
+func (dt *datetime) calculateWorkTime(dt1 datetime, dt2 datetime, operation int) error {
+	if err := holidays().err; err != nil {
+		return err
+	}
+
+	if dt2.kind == workTime && dt1.kind&timestamp != 0 && (operation == add || operation == sub) {
+		// Fri 15:00 + 6wh -> Mon 13:00
+		d := time.Duration(dt2.ts) * time.Second
+		if operation == sub {
+			d = -d
+		}
+		dt.kind = timestamp
+		dt.dt = addWorkTime(dt1.dt, d)
+		return nil
+	} else if dt1.kind == workTime && dt2.kind&timestamp != 0 && operation == add {
+		return dt.calculateWorkTime(dt2, dt1, add)
+	} else if dt1.kind&(workTime|duration) != 0 && dt2.kind&(workTime|duration) != 0 && (operation == add || operation == sub) {
+		// 6wh + 30m -> 6h30m of working time
+		dt.kind = workTime
+		dt.ts = dt1.ts + dt2.ts
+		if operation == sub {
+			dt.ts = dt1.ts - dt2.ts
+		}
+	} else if operation == mul && (dt1.kind&number != 0 || dt2.kind&number != 0) {
+		dt.kind = workTime
+		dt.ts = dt1.ts * dt2.ts
+	} else if operation == div && dt1.kind == workTime && dt2.kind&number != 0 {
+		if dt2.ts == 0 {
+			return errors.New(tr("division by zero"))
+		}
+		dt.kind = workTime
+		dt.ts = dt1.ts / dt2.ts
+	} else {
+		return errors.New(tr("working time goes with dates, durations and numbers only"))
+	}
+	dt.updateDT(ts)
+	return nil
+}
+
+// `work hours between <date> and <date>`
+func workTimeItems(match []string) Items {
+	var dates [2]time.Time
+
+	for i, p := range match[1:] {
+		dt, err := evaluateDate(p, loadEnvironment())
+		if err == nil && dt.kind != timestamp {
+			err = errors.New(tr("work hours needs two timestamps"))
+		}
+		if err != nil {
+			return getItems(datetime{}, err)
+		}
+		dates[i] = dt.dt
+	}
+
+	if err := holidays().err; err != nil {
+		return getItems(datetime{}, err)
+	}
+
+	d := workTimeBetween(dates[0], dates[1])
+	return getItems(newDuration(int64(d.Seconds())), nil)
+}